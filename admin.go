@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CreateOrderItem struct {
+	ItemID   int64 `json:"item_id" example:"34001"`
+	ModelID  int64 `json:"model_id" example:"0"`
+	Quantity int   `json:"quantity" example:"1"`
+}
+
+type CreateOrderRequest struct {
+	ShopID              int64             `json:"shop_id" example:"789012"`
+	OrderSN             string            `json:"order_sn" example:"2404098R48U37H"`
+	OrderStatus         string            `json:"order_status" example:"READY_TO_SHIP"`
+	MessageToSeller     string            `json:"message_to_seller,omitempty" example:"Please pack carefully"`
+	ItemList            []CreateOrderItem `json:"item_list"`
+	Invoice             *BuyerInvoice     `json:"invoice,omitempty"`
+	VoucherCode         string            `json:"voucher_code,omitempty" example:"PAY10"`
+	ShopeeVoucherAmount float64           `json:"shopee_voucher_amount,omitempty" example:"10"`
+}
+
+type CreateOrderResponse struct {
+	RequestID string       `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string       `json:"error" example:""`
+	Message   string       `json:"message" example:""`
+	Warning   string       `json:"warning,omitempty" example:""`
+	Response  *OrderDetail `json:"response,omitempty"`
+}
+
+// adminCreateOrder places a new order in the mock
+// @Summary Create order
+// @Description Places a new order in any known status. Stock for every line item that refers to a known item is reserved while the order has not shipped, deducted if it is created already shipped, and left alone if it is created cancelled; an unknown order_status is rejected with error_param. Records the buyer's invoice request when one is given, with a warning if its tax ID is invalid for the shop's region. A voucher_code applies one of the shop's ongoing vouchers and uses it up once; shopee_voucher_amount is taken off as a voucher funded by Shopee. Both lower total_amount and show in the escrow detail.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body CreateOrderRequest true "Order to create"
+// @Success 200 {object} CreateOrderResponse "Created order"
+// @Failure 400 {object} CreateOrderResponse "Bad request"
+// @Router /admin/orders [post]
+func adminCreateOrder(c *fiber.Ctx) error {
+	var req CreateOrderRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(CreateOrderResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if len(req.ItemList) == 0 {
+		return c.Status(400).JSON(CreateOrderResponse{
+			Error:   "error_param",
+			Message: "item_list is required",
+		})
+	}
+
+	if req.Invoice != nil {
+		if err := req.Invoice.validate(); err != nil {
+			return c.Status(400).JSON(CreateOrderResponse{
+				Error:   "error_param",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	now := clock.Now()
+	shopID := resolveShopID(req.ShopID)
+	order, err := buildOrder(req, now)
+	if err == nil && order.VoucherCode != "" {
+		err = vouchers.redeem(shopID, order.VoucherCode, now)
+	}
+	if err == nil {
+		voucherCode := order.VoucherCode
+		order, err = store.createOrder(shopID, order)
+		if err != nil && voucherCode != "" {
+			vouchers.release(shopID, voucherCode, now)
+		}
+	}
+	if err == nil && req.Invoice != nil {
+		_, err = store.setInvoice(order.OrderSN, req.Invoice)
+	}
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(CreateOrderResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(CreateOrderResponse{
+		RequestID: newRequestID(),
+		Warning:   taxIDWarning(order.Region, req.Invoice),
+		Response:  &order,
+	})
+}
+
+// buildOrder fills the canned order with the requested line items, taking
+// names and prices from the shop's products and its region and currency
+// from the shop.
+func buildOrder(req CreateOrderRequest, now time.Time) (OrderDetail, error) {
+	shop, ok := tenants.shop(resolveShopID(req.ShopID))
+	if !ok {
+		return OrderDetail{}, errShopNotFound
+	}
+
+	orderSN := req.OrderSN
+	if orderSN == "" {
+		orderSN = newOrderSN(now)
+	}
+
+	profile := regionProfile(shop.Region)
+	order := newRegionalMockOrder(orderSN, shop)
+	order.OrderStatus = "READY_TO_SHIP"
+	if req.OrderStatus != "" {
+		if _, ok := orderStatuses[req.OrderStatus]; !ok {
+			return OrderDetail{}, fmt.Errorf("%w %s", errUnknownStatus, req.OrderStatus)
+		}
+		order.OrderStatus = req.OrderStatus
+	}
+	order.MessageToSeller = req.MessageToSeller
+	order.CreateTime = now.Unix()
+	order.UpdateTime = now.Unix()
+	order.PayTime = now.Unix()
+	switch order.OrderStatus {
+	case "UNPAID":
+		order.PayTime = 0
+	case "PENDING":
+		// Payment is made but still being verified, which never happens
+		// for cash on delivery
+		order.PayTime = 0
+		if order.COD {
+			order.COD = false
+			for _, method := range profile.PaymentMethods {
+				if method != "Cash on Delivery" {
+					order.PaymentMethod = method
+					break
+				}
+			}
+		}
+	}
+	order.PickupDoneTime = 0
+	order.ShipByDate = now.AddDate(0, 0, order.DaysToShip).Unix()
+
+	order.ItemList = nil
+	order.TotalAmount = 0
+	var packageItems []PackageItemDetail
+	for _, line := range req.ItemList {
+		item, ok := store.getItem(shop.ShopID, line.ItemID)
+		if !ok {
+			return OrderDetail{}, errItemNotFound
+		}
+		applyDiscount(shop.ShopID, &item, now)
+		var promotionType string
+		if item.PromotionID != 0 {
+			promotionType = discountPromotionType
+		}
+		quantity := line.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+
+		var originalPrice, currentPrice float64
+		if len(item.PriceInfo) > 0 {
+			originalPrice = profile.price(item.PriceInfo[0].OriginalPrice)
+			currentPrice = profile.price(item.PriceInfo[0].CurrentPrice)
+		}
+		var imageURL string
+		if len(item.Image.ImageURLList) > 0 {
+			imageURL = item.Image.ImageURLList[0]
+		}
+
+		order.ItemList = append(order.ItemList, OrderItem{
+			ImageInfo:              ImageInfo{ImageURL: imageURL},
+			ItemID:                 item.ItemID,
+			ItemName:               item.ItemName,
+			ItemSKU:                item.ItemSKU,
+			ModelDiscountedPrice:   currentPrice,
+			ModelID:                line.ModelID,
+			ModelOriginalPrice:     originalPrice,
+			ModelQuantityPurchased: quantity,
+			OrderItemID:            item.ItemID,
+			ProductLocationID:      []string{profile.Region + "Z"},
+			PromotionID:            item.PromotionID,
+			PromotionType:          promotionType,
+		})
+		packageItems = append(packageItems, PackageItemDetail{
+			ItemID:            item.ItemID,
+			ModelID:           line.ModelID,
+			ModelQuantity:     quantity,
+			OrderItemID:       item.ItemID,
+			ProductLocationID: profile.Region + "Z",
+		})
+		order.TotalAmount += currentPrice * float64(quantity)
+	}
+	order.TotalAmount = profile.price(order.TotalAmount)
+
+	if req.VoucherCode != "" {
+		amount, err := vouchers.quote(shop.ShopID, req.VoucherCode, order.ItemList, profile.PriceDecimals, now)
+		if err != nil {
+			return OrderDetail{}, err
+		}
+		order.VoucherCode = strings.ToUpper(req.VoucherCode)
+		order.VoucherFromSeller = amount
+	}
+	if req.ShopeeVoucherAmount < 0 || req.ShopeeVoucherAmount > order.TotalAmount-order.VoucherFromSeller {
+		return OrderDetail{}, fmt.Errorf("%w: shopee_voucher_amount must be between 0 and the order total", errInvalidVoucher)
+	}
+	order.VoucherFromShopee = profile.price(req.ShopeeVoucherAmount)
+	order.TotalAmount = profile.price(order.TotalAmount - order.VoucherFromSeller - order.VoucherFromShopee)
+
+	order.PackageList[0].PackageNumber = "OFG" + randomDigits(15)
+	order.PackageList[0].ItemList = packageItems
+	order.PackageList[0].LogisticsStatus = "LOGISTICS_READY"
+	return order, nil
+}
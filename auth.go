@@ -13,8 +13,16 @@ import (
 
 const (
 	PartnerKey = "your_partner_key_here"
+
+	// maxTimestampSkew is how far, in seconds, a request timestamp may be
+	// from the server's clock.
+	maxTimestampSkew = 300
 )
 
+// checkTimestamps turns on validateTimestamp for every API request. It is
+// off by default so saved requests and hand-written examples keep working.
+var checkTimestamps = getEnv("CHECK_TIMESTAMP", "") == "true"
+
 func validateShopeeSignature(c *fiber.Ctx) error {
 	partnerID := c.Query("partner_id")
 	timestamp := c.Query("timestamp")
@@ -71,6 +79,15 @@ func validateTimestamp(c *fiber.Ctx) error {
 		})
 	}
 
+	// Shopee rejects requests signed more than five minutes away from its
+	// own time; the mock compares against the virtual clock.
+	if skew := clock.Now().Unix() - timestamp; skew > maxTimestampSkew || skew < -maxTimestampSkew {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "invalid_timestamp",
+			"message": "Timestamp is expired",
+		})
+	}
+
 	c.Locals("timestamp", timestamp)
 	return c.Next()
 }
\ No newline at end of file
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestValidateTimestamp(t *testing.T) {
+	app := fiber.New()
+	app.Use(validateTimestamp)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+
+	now := clock.Now().Unix()
+	tests := []struct {
+		timestamp  string
+		wantStatus int
+		wantError  string
+	}{
+		{strconv.FormatInt(now, 10), 200, ""},
+		{strconv.FormatInt(now-maxTimestampSkew, 10), 200, ""},
+		{strconv.FormatInt(now-maxTimestampSkew-60, 10), 400, "invalid_timestamp"},
+		{strconv.FormatInt(now+maxTimestampSkew+60, 10), 400, "invalid_timestamp"},
+		{"soon", 400, "invalid_timestamp"},
+		{"", 400, "missing_timestamp"},
+	}
+
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", "/?timestamp="+tt.timestamp, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("timestamp %q: status = %d, want %d", tt.timestamp, resp.StatusCode, tt.wantStatus)
+			continue
+		}
+		if tt.wantError == "" {
+			continue
+		}
+		var body struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Error != tt.wantError {
+			t.Errorf("timestamp %q: error = %q, want %q", tt.timestamp, body.Error, tt.wantError)
+		}
+	}
+}
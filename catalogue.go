@@ -0,0 +1,478 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Category struct {
+	CategoryID           int64  `json:"category_id" example:"14646"`
+	ParentCategoryID     int64  `json:"parent_category_id" example:"14644"`
+	OriginalCategoryName string `json:"original_category_name" example:"Default L3"`
+	DisplayCategoryName  string `json:"display_category_name" example:"Default L3"`
+	HasChildren          bool   `json:"has_children" example:"false"`
+}
+
+type CatalogueAttributeValue struct {
+	ValueID           int64  `json:"value_id" example:"61"`
+	OriginalValueName string `json:"original_value_name" example:"Default"`
+	DisplayValueName  string `json:"display_value_name" example:"Default"`
+	ValueUnit         string `json:"value_unit" example:"g"`
+}
+
+type CatalogueAttribute struct {
+	AttributeID           int64                     `json:"attribute_id" example:"4811"`
+	OriginalAttributeName string                    `json:"original_attribute_name" example:"Brand: L2 Default [14644]"`
+	DisplayAttributeName  string                    `json:"display_attribute_name" example:"Brand: L2 Default [14644]"`
+	IsMandatory           bool                      `json:"is_mandatory" example:"true"`
+	InputValidationType   string                    `json:"input_validation_type" example:"STRING_TYPE"`
+	FormatType            string                    `json:"format_type" example:"NORMAL"`
+	InputType             string                    `json:"input_type" example:"COMBO_BOX"`
+	AttributeUnit         []string                  `json:"attribute_unit" example:"g,kg"`
+	AttributeValueList    []CatalogueAttributeValue `json:"attribute_value_list"`
+}
+
+type CatalogueBrand struct {
+	BrandID           int64  `json:"brand_id" example:"123"`
+	OriginalBrandName string `json:"original_brand_name" example:"nike"`
+	DisplayBrandName  string `json:"display_brand_name" example:"Nike"`
+}
+
+// CatalogueCategory is one node of a loadable category tree. Attributes and
+// brands are listed on the categories items may be created in.
+type CatalogueCategory struct {
+	CategoryID           int64                `json:"category_id" example:"14646"`
+	ParentCategoryID     int64                `json:"parent_category_id" example:"14644"`
+	OriginalCategoryName string               `json:"original_category_name" example:"Default L3"`
+	DisplayCategoryName  string               `json:"display_category_name" example:"Default L3"`
+	BrandIsMandatory     bool                 `json:"brand_is_mandatory" example:"false"`
+	AttributeList        []CatalogueAttribute `json:"attribute_list"`
+	BrandList            []CatalogueBrand     `json:"brand_list"`
+}
+
+type Catalogue struct {
+	CategoryList []CatalogueCategory `json:"category_list"`
+}
+
+type CategoryListResponse struct {
+	CategoryList []Category `json:"category_list"`
+}
+
+type GetCategoryResponse struct {
+	Error     string               `json:"error" example:""`
+	Message   string               `json:"message" example:""`
+	Warning   string               `json:"warning" example:""`
+	RequestID string               `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
+	Response  CategoryListResponse `json:"response"`
+}
+
+type GetAttributesRequest struct {
+	CategoryID int64  `json:"category_id" query:"category_id" example:"14646"`
+	Language   string `json:"language" query:"language" example:"en"`
+}
+
+type AttributeListResponse struct {
+	AttributeList []CatalogueAttribute `json:"attribute_list"`
+}
+
+type GetAttributesResponse struct {
+	Error     string                `json:"error" example:""`
+	Message   string                `json:"message" example:""`
+	Warning   string                `json:"warning" example:""`
+	RequestID string                `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
+	Response  AttributeListResponse `json:"response"`
+}
+
+type GetBrandListRequest struct {
+	CategoryID int64 `json:"category_id" query:"category_id" example:"14646"`
+	Status     int   `json:"status" query:"status" example:"1"`
+	Offset     int   `json:"offset" query:"offset" example:"0"`
+	PageSize   int   `json:"page_size" query:"page_size" example:"10"`
+}
+
+type BrandListResponse struct {
+	BrandList   []CatalogueBrand `json:"brand_list"`
+	HasNextPage bool             `json:"has_next_page" example:"false"`
+	NextOffset  int              `json:"next_offset" example:"0"`
+	IsMandatory bool             `json:"is_mandatory" example:"false"`
+	InputType   string           `json:"input_type" example:"DROP_DOWN"`
+}
+
+type GetBrandListResponse struct {
+	Error     string            `json:"error" example:""`
+	Message   string            `json:"message" example:""`
+	Warning   string            `json:"warning" example:""`
+	RequestID string            `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
+	Response  BrandListResponse `json:"response"`
+}
+
+var errInvalidItem = errors.New("invalid item")
+
+// catalogueStore holds the category tree that item writes are validated
+// against. The tree is replaced wholesale when a new one is loaded.
+type catalogueStore struct {
+	mu         sync.RWMutex
+	categories map[int64]*CatalogueCategory
+	order      []int64
+}
+
+var catalogue = newCatalogueStore(defaultCatalogue())
+
+func newCatalogueStore(c Catalogue) *catalogueStore {
+	s := &catalogueStore{}
+	s.replace(c)
+	return s
+}
+
+// loadCatalogueFile replaces the catalogue with the tree in a JSON file.
+func loadCatalogueFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var c Catalogue
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("parse catalogue %s: %w", path, err)
+	}
+	return catalogue.replace(c)
+}
+
+func (s *catalogueStore) replace(c Catalogue) error {
+	categories := make(map[int64]*CatalogueCategory, len(c.CategoryList))
+	order := make([]int64, 0, len(c.CategoryList))
+	for i := range c.CategoryList {
+		category := &c.CategoryList[i]
+		if _, ok := categories[category.CategoryID]; ok {
+			return fmt.Errorf("duplicate category_id %d", category.CategoryID)
+		}
+		categories[category.CategoryID] = category
+		order = append(order, category.CategoryID)
+	}
+	for _, category := range categories {
+		if category.ParentCategoryID == 0 {
+			continue
+		}
+		if _, ok := categories[category.ParentCategoryID]; !ok {
+			return fmt.Errorf("category %d has unknown parent_category_id %d", category.CategoryID, category.ParentCategoryID)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories = categories
+	s.order = order
+	return nil
+}
+
+func (s *catalogueStore) hasChildren(categoryID int64) bool {
+	for _, category := range s.categories {
+		if category.ParentCategoryID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *catalogueStore) categoryList() []Category {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]Category, 0, len(s.order))
+	for _, categoryID := range s.order {
+		category := s.categories[categoryID]
+		list = append(list, Category{
+			CategoryID:           category.CategoryID,
+			ParentCategoryID:     category.ParentCategoryID,
+			OriginalCategoryName: category.OriginalCategoryName,
+			DisplayCategoryName:  category.DisplayCategoryName,
+			HasChildren:          s.hasChildren(category.CategoryID),
+		})
+	}
+	return list
+}
+
+func (s *catalogueStore) category(categoryID int64) (CatalogueCategory, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	category, ok := s.categories[categoryID]
+	if !ok {
+		return CatalogueCategory{}, false
+	}
+	return *category, true
+}
+
+// validateItem checks an item's category, attributes and brand against the
+// catalogue. It fills in attribute and brand names from the catalogue.
+func (s *catalogueStore) validateItem(item *ItemDetail) error {
+	if err := s.checkItem(item); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidItem, err)
+	}
+	return nil
+}
+
+func (s *catalogueStore) checkItem(item *ItemDetail) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	category, ok := s.categories[item.CategoryID]
+	if !ok {
+		return fmt.Errorf("category_id %d does not exist", item.CategoryID)
+	}
+	if s.hasChildren(category.CategoryID) {
+		return fmt.Errorf("category_id %d is not a leaf category", item.CategoryID)
+	}
+
+	attributes := make(map[int64]CatalogueAttribute, len(category.AttributeList))
+	for _, attribute := range category.AttributeList {
+		attributes[attribute.AttributeID] = attribute
+	}
+
+	seen := make(map[int64]bool, len(item.AttributeList))
+	for i := range item.AttributeList {
+		attr := &item.AttributeList[i]
+		known, ok := attributes[attr.AttributeID]
+		if !ok {
+			return fmt.Errorf("attribute_id %d is not valid for category_id %d", attr.AttributeID, item.CategoryID)
+		}
+		if err := validateAttributeValues(known, attr.AttributeValueList); err != nil {
+			return err
+		}
+		attr.OriginalAttributeName = known.OriginalAttributeName
+		attr.IsMandatory = known.IsMandatory
+		seen[attr.AttributeID] = true
+	}
+	for _, attribute := range category.AttributeList {
+		if attribute.IsMandatory && !seen[attribute.AttributeID] {
+			return fmt.Errorf("mandatory attribute_id %d is missing", attribute.AttributeID)
+		}
+	}
+
+	if item.Brand.BrandID == 0 {
+		if category.BrandIsMandatory {
+			return fmt.Errorf("brand is mandatory for category_id %d", item.CategoryID)
+		}
+		return nil
+	}
+	for _, brand := range category.BrandList {
+		if brand.BrandID == item.Brand.BrandID {
+			item.Brand.OriginalBrandName = brand.OriginalBrandName
+			return nil
+		}
+	}
+	return fmt.Errorf("brand_id %d is not valid for category_id %d", item.Brand.BrandID, item.CategoryID)
+}
+
+// validateAttributeValues checks values against an attribute's predefined
+// value list. A value_id of 0 is a custom value, which only free-text input
+// types accept.
+func validateAttributeValues(attribute CatalogueAttribute, values []AttributeValue) error {
+	if attribute.IsMandatory && len(values) == 0 {
+		return fmt.Errorf("attribute_id %d requires a value", attribute.AttributeID)
+	}
+	if len(values) > 1 && attribute.InputType != "MULTIPLE_SELECT" && attribute.InputType != "MULTIPLE_SELECT_COMBO_BOX" {
+		return fmt.Errorf("attribute_id %d accepts a single value", attribute.AttributeID)
+	}
+
+	for _, value := range values {
+		if value.ValueID == 0 {
+			switch attribute.InputType {
+			case "TEXT_FILED", "COMBO_BOX", "MULTIPLE_SELECT_COMBO_BOX":
+			default:
+				return fmt.Errorf("attribute_id %d only accepts predefined values", attribute.AttributeID)
+			}
+			if value.OriginalValueName == "" {
+				return fmt.Errorf("attribute_id %d custom value must have original_value_name", attribute.AttributeID)
+			}
+		} else if !hasAttributeValue(attribute, value.ValueID) {
+			return fmt.Errorf("value_id %d is not valid for attribute_id %d", value.ValueID, attribute.AttributeID)
+		}
+
+		if value.ValueUnit != "" && !containsString(attribute.AttributeUnit, value.ValueUnit) {
+			return fmt.Errorf("value_unit %q is not valid for attribute_id %d", value.ValueUnit, attribute.AttributeID)
+		}
+	}
+	return nil
+}
+
+func hasAttributeValue(attribute CatalogueAttribute, valueID int64) bool {
+	for _, value := range attribute.AttributeValueList {
+		if value.ValueID == valueID {
+			return true
+		}
+	}
+	return false
+}
+
+// getCategory retrieves the category tree
+// @Summary Get category
+// @Description Retrieves every category in the catalogue, with parent links and leaf flags
+// @Tags Product
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param language query string false "Display language" example("en")
+// @Success 200 {object} GetCategoryResponse "Success response"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/product/get_category [get]
+func getCategory(c *fiber.Ctx) error {
+	return c.JSON(GetCategoryResponse{
+		RequestID: newRequestID(),
+		Response: CategoryListResponse{
+			CategoryList: catalogue.categoryList(),
+		},
+	})
+}
+
+// getAttributes retrieves the attributes of a category
+// @Summary Get attributes
+// @Description Retrieves the attributes, value lists and units that items in a category may use
+// @Tags Product
+// @Produce json
+// @Param category_id query int64 true "Category ID" example(14646)
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param language query string false "Display language" example("en")
+// @Success 200 {object} GetAttributesResponse "Success response"
+// @Failure 400 {object} GetAttributesResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/product/get_attributes [get]
+func getAttributes(c *fiber.Ctx) error {
+	var req GetAttributesRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(GetAttributesResponse{
+			Error:   "error_param",
+			Message: "Invalid query parameters",
+		})
+	}
+
+	category, ok := catalogue.category(req.CategoryID)
+	if !ok {
+		return c.Status(400).JSON(GetAttributesResponse{
+			Error:   "error_param",
+			Message: fmt.Sprintf("category_id %d does not exist", req.CategoryID),
+		})
+	}
+
+	attributes := category.AttributeList
+	if attributes == nil {
+		attributes = []CatalogueAttribute{}
+	}
+	return c.JSON(GetAttributesResponse{
+		RequestID: newRequestID(),
+		Response: AttributeListResponse{
+			AttributeList: attributes,
+		},
+	})
+}
+
+// getBrandList retrieves the brands of a category
+// @Summary Get brand list
+// @Description Retrieves a page of the brands items in a category may use
+// @Tags Product
+// @Produce json
+// @Param category_id query int64 true "Category ID" example(14646)
+// @Param status query int true "Brand status, 1 for normal and 2 for pending" example(1)
+// @Param offset query int true "Page offset" example(0)
+// @Param page_size query int true "Page size, at most 100" example(10)
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Success 200 {object} GetBrandListResponse "Success response"
+// @Failure 400 {object} GetBrandListResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/product/get_brand_list [get]
+func getBrandList(c *fiber.Ctx) error {
+	var req GetBrandListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(GetBrandListResponse{
+			Error:   "error_param",
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if req.PageSize <= 0 || req.PageSize > 100 || req.Offset < 0 {
+		return c.Status(400).JSON(GetBrandListResponse{
+			Error:   "error_param",
+			Message: "page_size must be between 1 and 100 and offset must not be negative",
+		})
+	}
+
+	category, ok := catalogue.category(req.CategoryID)
+	if !ok {
+		return c.Status(400).JSON(GetBrandListResponse{
+			Error:   "error_param",
+			Message: fmt.Sprintf("category_id %d does not exist", req.CategoryID),
+		})
+	}
+
+	// Every brand in the catalogue is approved, so pending lists are empty
+	brands := []CatalogueBrand{}
+	if req.Status != 2 && req.Offset < len(category.BrandList) {
+		end := req.Offset + req.PageSize
+		if end > len(category.BrandList) {
+			end = len(category.BrandList)
+		}
+		brands = category.BrandList[req.Offset:end]
+	}
+	nextOffset := req.Offset + len(brands)
+
+	return c.JSON(GetBrandListResponse{
+		RequestID: newRequestID(),
+		Response: BrandListResponse{
+			BrandList:   brands,
+			HasNextPage: req.Status != 2 && nextOffset < len(category.BrandList),
+			NextOffset:  nextOffset,
+			IsMandatory: category.BrandIsMandatory,
+			InputType:   "DROP_DOWN",
+		},
+	})
+}
+
+// adminLoadCatalogue replaces the category tree
+// @Summary Load catalogue
+// @Description Replaces the category, attribute and brand catalogue that item writes are validated against
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body Catalogue true "Category tree"
+// @Success 200 {object} map[string]interface{} "Catalogue loaded"
+// @Failure 400 {object} map[string]interface{} "Invalid catalogue"
+// @Router /admin/catalogue [put]
+func adminLoadCatalogue(c *fiber.Ctx) error {
+	var req Catalogue
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := catalogue.replace(req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   "",
+		"message": fmt.Sprintf("Loaded %d categories", len(req.CategoryList)),
+	})
+}
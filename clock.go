@@ -0,0 +1,255 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// clockTick is how often the clock checks for due timers while it runs.
+const clockTick = 100 * time.Millisecond
+
+type ClockState struct {
+	Now           int64 `json:"now" example:"1758274838"`
+	NowMilli      int64 `json:"now_ms" example:"1758274838123"`
+	Frozen        bool  `json:"frozen" example:"false"`
+	OffsetSeconds int64 `json:"offset_seconds" example:"0"`
+	PendingTimers int   `json:"pending_timers" example:"1"`
+}
+
+type ClockSetRequest struct {
+	Now    int64 `json:"now" example:"1758274838"`
+	Frozen *bool `json:"frozen,omitempty" example:"true"`
+}
+
+type ClockAdvanceRequest struct {
+	Seconds int64 `json:"seconds" example:"604800"`
+}
+
+type clockTimer struct {
+	at time.Time
+	fn func(now time.Time)
+}
+
+// virtualClock is the server's source of time. It follows the wall clock
+// shifted by an offset until it is frozen, and can be set or advanced from
+// the admin API. Timers scheduled on it fire once the virtual time reaches
+// them, whether it gets there by running or by being moved.
+type virtualClock struct {
+	mu       sync.Mutex
+	offset   time.Duration
+	frozen   bool
+	frozenAt time.Time
+	timers   []clockTimer
+}
+
+var clock = newVirtualClock()
+
+func newVirtualClock() *virtualClock {
+	c := &virtualClock{}
+	go func() {
+		for range time.Tick(clockTick) {
+			c.fireDue()
+		}
+	}()
+	return c
+}
+
+func (c *virtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now()
+}
+
+// now returns the virtual time. Callers must hold c.mu.
+func (c *virtualClock) now() time.Time {
+	if c.frozen {
+		return c.frozenAt
+	}
+	return time.Now().Add(c.offset)
+}
+
+func (c *virtualClock) state() ClockState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	return ClockState{
+		Now:           now.Unix(),
+		NowMilli:      now.UnixMilli(),
+		Frozen:        c.frozen,
+		OffsetSeconds: int64(now.Sub(time.Now()).Round(time.Second).Seconds()),
+		PendingTimers: len(c.timers),
+	}
+}
+
+func (c *virtualClock) freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.frozen {
+		c.frozenAt = c.now()
+		c.frozen = true
+	}
+}
+
+// resume lets a frozen clock run again from the time it was frozen at.
+func (c *virtualClock) resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.frozen {
+		c.offset = time.Until(c.frozenAt)
+		c.frozen = false
+	}
+}
+
+// set moves the clock to t, firing every timer due by then. A frozen clock
+// stays frozen at t.
+func (c *virtualClock) set(t time.Time) {
+	c.mu.Lock()
+	if c.frozen {
+		c.frozenAt = t
+	} else {
+		c.offset = time.Until(t)
+	}
+	c.mu.Unlock()
+	c.fireDue()
+}
+
+func (c *virtualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	if c.frozen {
+		c.frozenAt = c.frozenAt.Add(d)
+	} else {
+		c.offset += d
+	}
+	c.mu.Unlock()
+	c.fireDue()
+}
+
+// schedule runs fn once the clock reaches at. fn is called without any
+// clock lock held and receives the time it was due at.
+func (c *virtualClock) schedule(at time.Time, fn func(now time.Time)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timers = append(c.timers, clockTimer{at: at, fn: fn})
+}
+
+// fireDue runs the due timers in the order they are due.
+func (c *virtualClock) fireDue() {
+	c.mu.Lock()
+	now := c.now()
+	var due, pending []clockTimer
+	for _, timer := range c.timers {
+		if timer.at.After(now) {
+			pending = append(pending, timer)
+		} else {
+			due = append(due, timer)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].at.Before(due[j].at) })
+	for _, timer := range due {
+		timer.fn(timer.at)
+	}
+}
+
+func clockError(c *fiber.Ctx, err error) error {
+	return c.Status(400).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminGetClock returns the virtual clock
+// @Summary Get clock
+// @Description Returns the server's virtual time, whether it is frozen and how many timers are pending
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ClockState "Clock state"
+// @Router /admin/clock [get]
+func adminGetClock(c *fiber.Ctx) error {
+	return c.JSON(clock.state())
+}
+
+// adminSetClock moves the virtual clock to a point in time
+// @Summary Set clock
+// @Description Moves the virtual clock to a unix time, firing every timer due by then. The clock may also be frozen or resumed in the same call.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body ClockSetRequest true "New time, unix seconds"
+// @Success 200 {object} ClockState "Clock state"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/clock [put]
+func adminSetClock(c *fiber.Ctx) error {
+	var req ClockSetRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return clockError(c, errors.New("Invalid request body"))
+	}
+	if req.Now <= 0 {
+		return clockError(c, errors.New("now is required"))
+	}
+
+	if req.Frozen != nil && *req.Frozen {
+		clock.freeze()
+	}
+	clock.set(time.Unix(req.Now, 0))
+	if req.Frozen != nil && !*req.Frozen {
+		clock.resume()
+	}
+	return c.JSON(clock.state())
+}
+
+// adminAdvanceClock moves the virtual clock forward
+// @Summary Advance clock
+// @Description Moves the virtual clock forward, firing every timer due by then
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body ClockAdvanceRequest true "Seconds to advance"
+// @Success 200 {object} ClockState "Clock state"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/clock/advance [post]
+func adminAdvanceClock(c *fiber.Ctx) error {
+	var req ClockAdvanceRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return clockError(c, errors.New("Invalid request body"))
+	}
+	if req.Seconds <= 0 {
+		return clockError(c, errors.New("seconds must be positive"))
+	}
+
+	clock.advance(time.Duration(req.Seconds) * time.Second)
+	return c.JSON(clock.state())
+}
+
+// adminFreezeClock stops the virtual clock
+// @Summary Freeze clock
+// @Description Stops the virtual clock at its current time until it is resumed
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ClockState "Clock state"
+// @Router /admin/clock/freeze [post]
+func adminFreezeClock(c *fiber.Ctx) error {
+	clock.freeze()
+	return c.JSON(clock.state())
+}
+
+// adminResumeClock lets the virtual clock run again
+// @Summary Resume clock
+// @Description Lets a frozen virtual clock run again from the time it was frozen at
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ClockState "Clock state"
+// @Router /admin/clock/resume [post]
+func adminResumeClock(c *fiber.Ctx) error {
+	clock.resume()
+	return c.JSON(clock.state())
+}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	maxDiscountDuration = 180 * 24 * time.Hour
+	minDiscountDuration = time.Hour
+	maxDiscountPageSize = 100
+
+	// discountPromotionType is the promotion_type order items bought at a
+	// discount price carry.
+	discountPromotionType = "product_promotion"
+)
+
+var (
+	errDiscountNotFound = errors.New("discount not found")
+	errInvalidDiscount  = errors.New("invalid discount")
+	errDiscountExpired  = errors.New("discount has expired")
+)
+
+type AddDiscountRequest struct {
+	DiscountName string `json:"discount_name" example:"Payday sale"`
+	StartTime    int64  `json:"start_time" example:"1758279600"`
+	EndTime      int64  `json:"end_time" example:"1758884400"`
+}
+
+type DiscountIDResult struct {
+	DiscountID int64 `json:"discount_id" example:"1000001"`
+}
+
+type AddDiscountResponse struct {
+	RequestID string            `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string            `json:"error" example:""`
+	Message   string            `json:"message" example:""`
+	Response  *DiscountIDResult `json:"response,omitempty"`
+}
+
+type AddDiscountItem struct {
+	ItemID             int64   `json:"item_id" example:"34001"`
+	ItemPromotionPrice float64 `json:"item_promotion_price" example:"99"`
+	PurchaseLimit      int     `json:"purchase_limit" example:"2"`
+}
+
+type AddDiscountItemRequest struct {
+	DiscountID int64             `json:"discount_id" example:"1000001"`
+	ItemList   []AddDiscountItem `json:"item_list"`
+}
+
+type DiscountItemError struct {
+	ItemID      int64  `json:"item_id" example:"34002"`
+	FailError   string `json:"fail_error" example:"error_param"`
+	FailMessage string `json:"fail_message" example:"item_promotion_price must be below the item's original price"`
+}
+
+type AddDiscountItemResult struct {
+	DiscountID int64               `json:"discount_id" example:"1000001"`
+	Count      int                 `json:"count" example:"1"`
+	ErrorList  []DiscountItemError `json:"error_list"`
+}
+
+type AddDiscountItemResponse struct {
+	RequestID string                 `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string                 `json:"error" example:""`
+	Message   string                 `json:"message" example:""`
+	Response  *AddDiscountItemResult `json:"response,omitempty"`
+}
+
+type DiscountItem struct {
+	ItemID             int64   `json:"item_id" example:"34001"`
+	ItemName           string  `json:"item_name" example:"Mock Item"`
+	ItemOriginalPrice  float64 `json:"item_original_price" example:"122.02"`
+	ItemPromotionPrice float64 `json:"item_promotion_price" example:"99"`
+	PurchaseLimit      int     `json:"purchase_limit" example:"2"`
+}
+
+type Discount struct {
+	DiscountID   int64          `json:"discount_id" example:"1000001"`
+	DiscountName string         `json:"discount_name" example:"Payday sale"`
+	StartTime    int64          `json:"start_time" example:"1758279600"`
+	EndTime      int64          `json:"end_time" example:"1758884400"`
+	Status       string         `json:"status" example:"upcoming"`
+	ItemList     []DiscountItem `json:"item_list"`
+}
+
+type DiscountDetailResult struct {
+	Discount
+	More bool `json:"more" example:"false"`
+}
+
+type GetDiscountResponse struct {
+	RequestID string                `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string                `json:"error" example:""`
+	Message   string                `json:"message" example:""`
+	Response  *DiscountDetailResult `json:"response,omitempty"`
+}
+
+type DiscountSummary struct {
+	DiscountID   int64  `json:"discount_id" example:"1000001"`
+	DiscountName string `json:"discount_name" example:"Payday sale"`
+	StartTime    int64  `json:"start_time" example:"1758279600"`
+	EndTime      int64  `json:"end_time" example:"1758884400"`
+	Status       string `json:"status" example:"upcoming"`
+}
+
+type DiscountListResult struct {
+	DiscountList []DiscountSummary `json:"discount_list"`
+	More         bool              `json:"more" example:"false"`
+}
+
+type GetDiscountListResponse struct {
+	RequestID string              `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string              `json:"error" example:""`
+	Message   string              `json:"message" example:""`
+	Response  *DiscountListResult `json:"response,omitempty"`
+}
+
+type EndDiscountRequest struct {
+	DiscountID int64 `json:"discount_id" example:"1000001"`
+}
+
+type EndDiscountResult struct {
+	DiscountID int64 `json:"discount_id" example:"1000001"`
+	ModifyTime int64 `json:"modify_time" example:"1758274838"`
+}
+
+type EndDiscountResponse struct {
+	RequestID string             `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string             `json:"error" example:""`
+	Message   string             `json:"message" example:""`
+	Response  *EndDiscountResult `json:"response,omitempty"`
+}
+
+type shopDiscount struct {
+	Discount
+	shopID int64
+}
+
+// status is where a discount is in its lifetime at a given time.
+func (d *shopDiscount) status(now time.Time) string {
+	switch {
+	case now.Unix() < d.StartTime:
+		return "upcoming"
+	case now.Unix() < d.EndTime:
+		return "ongoing"
+	default:
+		return "expired"
+	}
+}
+
+func (d *shopDiscount) item(itemID int64) (DiscountItem, bool) {
+	for _, item := range d.ItemList {
+		if item.ItemID == itemID {
+			return item, true
+		}
+	}
+	return DiscountItem{}, false
+}
+
+// overlaps reports whether a discount runs at any time during another's.
+func (d *shopDiscount) overlaps(other *shopDiscount) bool {
+	return d.StartTime < other.EndTime && other.StartTime < d.EndTime
+}
+
+// discountStore keeps each shop's discounts. Discounts are never deleted;
+// ending one moves its end time to the moment it was ended.
+type discountStore struct {
+	mu        sync.Mutex
+	discounts map[int64]*shopDiscount
+	nextID    int64
+}
+
+var discounts = &discountStore{
+	discounts: make(map[int64]*shopDiscount),
+	nextID:    1000001,
+}
+
+// discount returns a shop's discount. Callers must hold s.mu.
+func (s *discountStore) discount(shopID, discountID int64) (*shopDiscount, bool) {
+	discount, ok := s.discounts[discountID]
+	if !ok || discount.shopID != shopID {
+		return nil, false
+	}
+	return discount, true
+}
+
+func (s *discountStore) add(shopID int64, req AddDiscountRequest, now time.Time) (int64, error) {
+	start, end := time.Unix(req.StartTime, 0), time.Unix(req.EndTime, 0)
+	switch {
+	case strings.TrimSpace(req.DiscountName) == "":
+		return 0, fmt.Errorf("%w: discount_name is required", errInvalidDiscount)
+	case !start.After(now):
+		return 0, fmt.Errorf("%w: start_time must be in the future", errInvalidDiscount)
+	case end.Sub(start) < minDiscountDuration:
+		return 0, fmt.Errorf("%w: end_time must be at least an hour after start_time", errInvalidDiscount)
+	case end.Sub(start) > maxDiscountDuration:
+		return 0, fmt.Errorf("%w: a discount can run for at most 180 days", errInvalidDiscount)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.discounts[id] = &shopDiscount{
+		Discount: Discount{
+			DiscountID:   id,
+			DiscountName: req.DiscountName,
+			StartTime:    req.StartTime,
+			EndTime:      req.EndTime,
+			ItemList:     []DiscountItem{},
+		},
+		shopID: shopID,
+	}
+	return id, nil
+}
+
+// addItems puts items in a discount at their promotion prices. Items that
+// cannot be added are reported one by one without failing the others.
+func (s *discountStore) addItems(shopID, discountID int64, items []AddDiscountItem, now time.Time) (int, []DiscountItemError, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discount, ok := s.discount(shopID, discountID)
+	if !ok {
+		return 0, nil, errDiscountNotFound
+	}
+	if discount.status(now) == "expired" {
+		return 0, nil, errDiscountExpired
+	}
+
+	count := 0
+	failures := []DiscountItemError{}
+	fail := func(itemID int64, err error) {
+		_, code := storeError(err)
+		failures = append(failures, DiscountItemError{ItemID: itemID, FailError: code, FailMessage: err.Error()})
+	}
+	for _, line := range items {
+		item, ok := store.getItem(shopID, line.ItemID)
+		if !ok {
+			fail(line.ItemID, errItemNotFound)
+			continue
+		}
+		var originalPrice float64
+		if len(item.PriceInfo) > 0 {
+			originalPrice = item.PriceInfo[0].OriginalPrice
+		}
+		if line.ItemPromotionPrice <= 0 || line.ItemPromotionPrice >= originalPrice {
+			fail(line.ItemID, fmt.Errorf("%w: item_promotion_price must be above 0 and below the item's original price", errInvalidDiscount))
+			continue
+		}
+		if line.PurchaseLimit < 0 {
+			fail(line.ItemID, fmt.Errorf("%w: purchase_limit must not be negative", errInvalidDiscount))
+			continue
+		}
+		if other, ok := s.overlapping(discount, line.ItemID, now); ok {
+			fail(line.ItemID, fmt.Errorf("%w: item is already in discount %d at the same time", errInvalidDiscount, other))
+			continue
+		}
+
+		entry := DiscountItem{
+			ItemID:             item.ItemID,
+			ItemName:           item.ItemName,
+			ItemOriginalPrice:  originalPrice,
+			ItemPromotionPrice: roundPrice(line.ItemPromotionPrice, currencyDecimals(item.PriceInfo[0].Currency)),
+			PurchaseLimit:      line.PurchaseLimit,
+		}
+		replaced := false
+		for i := range discount.ItemList {
+			if discount.ItemList[i].ItemID == entry.ItemID {
+				discount.ItemList[i] = entry
+				replaced = true
+			}
+		}
+		if !replaced {
+			discount.ItemList = append(discount.ItemList, entry)
+		}
+		count++
+	}
+	return count, failures, nil
+}
+
+// overlapping finds another unexpired discount that has the item during
+// the discount's time. Callers must hold s.mu.
+func (s *discountStore) overlapping(discount *shopDiscount, itemID int64, now time.Time) (int64, bool) {
+	for _, other := range s.discounts {
+		if other == discount || other.shopID != discount.shopID || other.status(now) == "expired" {
+			continue
+		}
+		if _, ok := other.item(itemID); ok && other.overlaps(discount) {
+			return other.DiscountID, true
+		}
+	}
+	return 0, false
+}
+
+func (s *discountStore) get(shopID, discountID int64, now time.Time) (Discount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discount, ok := s.discount(shopID, discountID)
+	if !ok {
+		return Discount{}, errDiscountNotFound
+	}
+	result := discount.Discount
+	result.Status = discount.status(now)
+	result.ItemList = append([]DiscountItem(nil), discount.ItemList...)
+	return result, nil
+}
+
+// list returns a shop's discounts in a status, or all of them, newest
+// first.
+func (s *discountStore) list(shopID int64, status string, now time.Time) []DiscountSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []DiscountSummary{}
+	for _, discount := range s.discounts {
+		if discount.shopID != shopID {
+			continue
+		}
+		current := discount.status(now)
+		if status != "all" && current != status {
+			continue
+		}
+		list = append(list, DiscountSummary{
+			DiscountID:   discount.DiscountID,
+			DiscountName: discount.DiscountName,
+			StartTime:    discount.StartTime,
+			EndTime:      discount.EndTime,
+			Status:       current,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].DiscountID > list[j].DiscountID })
+	return list
+}
+
+// end stops an ongoing discount now.
+func (s *discountStore) end(shopID, discountID int64, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discount, ok := s.discount(shopID, discountID)
+	if !ok {
+		return errDiscountNotFound
+	}
+	if discount.status(now) != "ongoing" {
+		return fmt.Errorf("%w: only ongoing discounts can be ended", errInvalidDiscount)
+	}
+	discount.EndTime = now.Unix()
+	return nil
+}
+
+// promotion returns the ongoing discount an item is in and its price
+// there.
+func (s *discountStore) promotion(shopID, itemID int64, now time.Time) (int64, DiscountItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, discount := range s.discounts {
+		if discount.shopID != shopID || discount.status(now) != "ongoing" {
+			continue
+		}
+		if item, ok := discount.item(itemID); ok {
+			return discount.DiscountID, item, true
+		}
+	}
+	return 0, DiscountItem{}, false
+}
+
+// applyDiscount sets an item's current price and promotion ID from the
+// discount it is in at the given time, if any. The item's price info is
+// copied first, as it shares its slice with the stored item.
+func applyDiscount(shopID int64, item *ItemDetail, now time.Time) {
+	discountID, entry, ok := discounts.promotion(shopID, item.ItemID, now)
+	if !ok {
+		return
+	}
+	item.PromotionID = discountID
+	item.PriceInfo = append([]PriceInfo(nil), item.PriceInfo...)
+	for i := range item.PriceInfo {
+		item.PriceInfo[i].CurrentPrice = entry.ItemPromotionPrice
+	}
+}
+
+// addDiscount creates a discount
+// @Summary Add discount
+// @Description Creates a discount that runs from start_time to end_time. It must start in the future and run for between an hour and 180 days.
+// @Tags Discount
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body AddDiscountRequest true "Discount to create"
+// @Success 200 {object} AddDiscountResponse "Success response"
+// @Failure 400 {object} AddDiscountResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/discount/add_discount [post]
+func addDiscount(c *fiber.Ctx) error {
+	var req AddDiscountRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(AddDiscountResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	discountID, err := discounts.add(currentShop(c).ShopID, req, clock.Now())
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(AddDiscountResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(AddDiscountResponse{
+		RequestID: newRequestID(),
+		Response:  &DiscountIDResult{DiscountID: discountID},
+	})
+}
+
+// addDiscountItem adds items to a discount
+// @Summary Add discount items
+// @Description Puts items in an upcoming or ongoing discount at their promotion prices, replacing the price of items already in it. While the discount is ongoing, the items' current_price and the prices on new orders are the promotion prices. Items that cannot be added are listed in error_list.
+// @Tags Discount
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body AddDiscountItemRequest true "Discount and items"
+// @Success 200 {object} AddDiscountItemResponse "Success response"
+// @Failure 400 {object} AddDiscountItemResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} AddDiscountItemResponse "Unknown discount"
+// @Router /api/v2/discount/add_discount_item [post]
+func addDiscountItem(c *fiber.Ctx) error {
+	var req AddDiscountItemRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(AddDiscountItemResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.DiscountID == 0 || len(req.ItemList) == 0 {
+		return c.Status(400).JSON(AddDiscountItemResponse{
+			Error:   "error_param",
+			Message: "discount_id and item_list are required",
+		})
+	}
+
+	count, failures, err := discounts.addItems(currentShop(c).ShopID, req.DiscountID, req.ItemList, clock.Now())
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(AddDiscountItemResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(AddDiscountItemResponse{
+		RequestID: newRequestID(),
+		Response: &AddDiscountItemResult{
+			DiscountID: req.DiscountID,
+			Count:      count,
+			ErrorList:  failures,
+		},
+	})
+}
+
+// getDiscount returns a discount and a page of its items
+// @Summary Get discount
+// @Description Returns a discount, its status on the virtual clock and a page of its items
+// @Tags Discount
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param discount_id query int64 true "Discount ID" example(1000001)
+// @Param page_no query int false "Page number, from 1" example(1)
+// @Param page_size query int false "Items per page, at most 100" example(100)
+// @Success 200 {object} GetDiscountResponse "Success response"
+// @Failure 400 {object} GetDiscountResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} GetDiscountResponse "Unknown discount"
+// @Router /api/v2/discount/get_discount [get]
+func getDiscount(c *fiber.Ctx) error {
+	discountID := int64(c.QueryInt("discount_id"))
+	if discountID == 0 {
+		return c.Status(400).JSON(GetDiscountResponse{
+			Error:   "error_param",
+			Message: "discount_id is required",
+		})
+	}
+
+	discount, err := discounts.get(currentShop(c).ShopID, discountID, clock.Now())
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(GetDiscountResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	start, end, err := pageRange(c.QueryInt("page_no"), c.QueryInt("page_size"), maxDiscountPageSize, maxDiscountPageSize, len(discount.ItemList))
+	if err != nil {
+		return c.Status(400).JSON(GetDiscountResponse{
+			RequestID: newRequestID(),
+			Error:     "error_param",
+			Message:   err.Error(),
+		})
+	}
+	more := end < len(discount.ItemList)
+	discount.ItemList = discount.ItemList[start:end]
+
+	return c.JSON(GetDiscountResponse{
+		RequestID: newRequestID(),
+		Response:  &DiscountDetailResult{Discount: discount, More: more},
+	})
+}
+
+// getDiscountList lists the shop's discounts
+// @Summary Get discount list
+// @Description Lists the shop's discounts in a status on the virtual clock, newest first
+// @Tags Discount
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param discount_status query string true "upcoming, ongoing, expired or all" example("ongoing")
+// @Param page_no query int false "Page number, from 1" example(1)
+// @Param page_size query int false "Discounts per page, at most 100" example(100)
+// @Success 200 {object} GetDiscountListResponse "Success response"
+// @Failure 400 {object} GetDiscountListResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/discount/get_discount_list [get]
+func getDiscountList(c *fiber.Ctx) error {
+	status := c.Query("discount_status")
+	switch status {
+	case "upcoming", "ongoing", "expired", "all":
+	default:
+		return c.Status(400).JSON(GetDiscountListResponse{
+			Error:   "error_param",
+			Message: "discount_status must be upcoming, ongoing, expired or all",
+		})
+	}
+
+	list := discounts.list(currentShop(c).ShopID, status, clock.Now())
+	start, end, err := pageRange(c.QueryInt("page_no"), c.QueryInt("page_size"), maxDiscountPageSize, maxDiscountPageSize, len(list))
+	if err != nil {
+		return c.Status(400).JSON(GetDiscountListResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(GetDiscountListResponse{
+		RequestID: newRequestID(),
+		Response: &DiscountListResult{
+			DiscountList: list[start:end],
+			More:         end < len(list),
+		},
+	})
+}
+
+// endDiscount ends an ongoing discount
+// @Summary End discount
+// @Description Ends an ongoing discount now, putting its items back at their normal prices
+// @Tags Discount
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body EndDiscountRequest true "Discount to end"
+// @Success 200 {object} EndDiscountResponse "Success response"
+// @Failure 400 {object} EndDiscountResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} EndDiscountResponse "Unknown discount"
+// @Router /api/v2/discount/end_discount [post]
+func endDiscount(c *fiber.Ctx) error {
+	var req EndDiscountRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(EndDiscountResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	now := clock.Now()
+	if err := discounts.end(currentShop(c).ShopID, req.DiscountID, now); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(EndDiscountResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(EndDiscountResponse{
+		RequestID: newRequestID(),
+		Response: &EndDiscountResult{
+			DiscountID: req.DiscountID,
+			ModifyTime: now.Unix(),
+		},
+	})
+}
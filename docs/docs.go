@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v2/order/get_buyer_invoice_info": {
-            "post": {
-                "description": "Retrieves buyer invoice information for a specific order",
+        "/admin/catalogue": {
+            "put": {
+                "description": "Replaces the category, attribute and brand catalogue that item writes are validated against",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,77 +25,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Admin"
                 ],
-                "summary": "Get buyer invoice information",
+                "summary": "Load catalogue",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 123456,
-                        "description": "Partner ID",
-                        "name": "partner_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 789012,
-                        "description": "Shop ID",
-                        "name": "shop_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 1640995200,
-                        "description": "Request timestamp",
-                        "name": "timestamp",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"your_access_token\"",
-                        "description": "Access token",
-                        "name": "access_token",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"ABCD1234567890EFGH\"",
-                        "description": "Signature",
-                        "name": "sign",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Request body with queries array",
+                        "description": "Category tree",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoRequest"
+                            "$ref": "#/definitions/main.Catalogue"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response",
+                        "description": "Catalogue loaded",
                         "schema": {
-                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid catalogue",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -104,9 +57,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/order/get_order_detail": {
-            "get": {
-                "description": "Retrieves detailed information about orders",
+        "/admin/chat/messages": {
+            "post": {
+                "description": "Adds a message from a buyer, named directly or as the buyer of order_sn, to their conversation with the shop, opening it if needed. The message counts as unread and is pushed to the webhook as a webchat push.",
                 "consumes": [
                     "application/json"
                 ],
@@ -114,90 +67,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Admin"
                 ],
-                "summary": "Get order details",
+                "summary": "Inject buyer chat message",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"201214JAJXU6G7,201214JASXYXY6\"",
-                        "description": "Comma-separated list of order serial numbers",
-                        "name": "order_sn_list",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 123456,
-                        "description": "Partner ID",
-                        "name": "partner_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 789012,
-                        "description": "Shop ID",
-                        "name": "shop_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 1640995200,
-                        "description": "Request timestamp",
-                        "name": "timestamp",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"your_access_token\"",
-                        "description": "Access token",
-                        "name": "access_token",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"ABCD1234567890EFGH\"",
-                        "description": "Signature",
-                        "name": "sign",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "example": true,
-                        "description": "Request pending order status",
-                        "name": "request_order_status_pending",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"total_amount\"",
-                        "description": "Optional response fields",
-                        "name": "response_optional_fields",
-                        "in": "query"
+                        "description": "Buyer and message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.InjectChatMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response",
+                        "description": "Injected message",
                         "schema": {
-                            "$ref": "#/definitions/main.GetOrderDetailResponse"
+                            "$ref": "#/definitions/main.ChatMessage"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid message",
                         "schema": {
-                            "$ref": "#/definitions/main.GetOrderDetailResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Unknown shop, order, item or buyer",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -206,9 +105,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/product/get_item_base_info": {
+        "/admin/clock": {
             "get": {
-                "description": "Retrieves detailed information about products",
+                "description": "Returns the server's virtual time, whether it is frozen and how many timers are pending",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get clock",
+                "responses": {
+                    "200": {
+                        "description": "Clock state",
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockState"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Moves the virtual clock to a unix time, firing every timer due by then. The clock may also be frozen or resumed in the same call.",
                 "consumes": [
                     "application/json"
                 ],
@@ -216,90 +133,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Admin"
                 ],
-                "summary": "Get item base information",
+                "summary": "Set clock",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"[34001,34002]\"",
-                        "description": "Array of item IDs",
-                        "name": "item_id_list",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "example": true,
-                        "description": "Include tax info in response",
-                        "name": "need_tax_info",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "example": true,
-                        "description": "Include complaint policy in response",
-                        "name": "need_complaint_policy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 123456,
-                        "description": "Partner ID",
-                        "name": "partner_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 789012,
-                        "description": "Shop ID",
-                        "name": "shop_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "example": 1640995200,
-                        "description": "Request timestamp",
-                        "name": "timestamp",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"your_access_token\"",
-                        "description": "Access token",
-                        "name": "access_token",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"ABCD1234567890EFGH\"",
-                        "description": "Signature",
-                        "name": "sign",
-                        "in": "query",
-                        "required": true
+                        "description": "New time, unix seconds",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockSetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response",
+                        "description": "Clock state",
                         "schema": {
-                            "$ref": "#/definitions/main.GetItemBaseInfoResponse"
+                            "$ref": "#/definitions/main.ClockState"
                         }
                     },
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "$ref": "#/definitions/main.GetItemBaseInfoResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/clock/advance": {
+            "post": {
+                "description": "Moves the virtual clock forward, firing every timer due by then",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Advance clock",
+                "parameters": [
+                    {
+                        "description": "Seconds to advance",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockAdvanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clock state",
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockState"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -307,268 +204,8708 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.AddressBreakdown": {
+        },
+        "/admin/clock/freeze": {
+            "post": {
+                "description": "Stops the virtual clock at its current time until it is resumed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Freeze clock",
+                "responses": {
+                    "200": {
+                        "description": "Clock state",
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockState"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/clock/resume": {
+            "post": {
+                "description": "Lets a frozen virtual clock run again from the time it was frozen at",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Resume clock",
+                "responses": {
+                    "200": {
+                        "description": "Clock state",
+                        "schema": {
+                            "$ref": "#/definitions/main.ClockState"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/faults": {
+            "get": {
+                "description": "Lists the fault injection rules in evaluation order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List fault rules",
+                "responses": {
+                    "200": {
+                        "description": "Rules",
+                        "schema": {
+                            "$ref": "#/definitions/main.FaultRuleListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a rule that injects latency, 5xx responses, timeouts, connection resets or Shopee business errors. A rule without a probability always fires; a probability of 0 never does.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add fault rule",
+                "parameters": [
+                    {
+                        "description": "Rule to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.FaultRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Added rule",
+                        "schema": {
+                            "$ref": "#/definitions/main.FaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes every fault injection rule",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear fault rules",
+                "responses": {
+                    "204": {
+                        "description": "Rules removed"
+                    }
+                }
+            }
+        },
+        "/admin/faults/{id}": {
+            "put": {
+                "description": "Replaces an existing fault injection rule, keeping its position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update fault rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.FaultRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated rule",
+                        "schema": {
+                            "$ref": "#/definitions/main.FaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes one fault injection rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete fault rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Rule removed"
+                    },
+                    "404": {
+                        "description": "Unknown rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/generate": {
+            "post": {
+                "description": "Creates items and orders for a shop with realistic distributions of status, basket size, item popularity, cash on delivery, vouchers, local buyer names and masked addresses. The same seed and now give the same data. Generated orders do not reserve stock or push status changes. Orders draw on the generated items, or on the shop's existing items when items is 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Generate data",
+                "parameters": [
+                    {
+                        "description": "Generator settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GenerateDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Summary of the generated data",
+                        "schema": {
+                            "$ref": "#/definitions/main.GenerateDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid settings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown shop",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/invoice_settings": {
+            "get": {
+                "description": "Returns whether get_buyer_invoice_info deliberately serves invalid tax IDs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get invoice settings",
+                "responses": {
+                    "200": {
+                        "description": "Current settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.InvoiceSettings"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "With invalid_tax_ids on, get_buyer_invoice_info alters every tax ID so it fails its region's format or check digit, and flags it in the entry's error, to exercise client-side validation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set invoice settings",
+                "parameters": [
+                    {
+                        "description": "New settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.InvoiceSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.InvoiceSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid settings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/merchants": {
+            "get": {
+                "description": "Lists every merchant with the shops it owns",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List merchants",
+                "responses": {
+                    "200": {
+                        "description": "Merchants",
+                        "schema": {
+                            "$ref": "#/definitions/main.MerchantListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a merchant account. Shops join it by naming its merchant_id when they are added.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add merchant",
+                "parameters": [
+                    {
+                        "description": "Merchant to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.Merchant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Added merchant",
+                        "schema": {
+                            "$ref": "#/definitions/main.Merchant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid merchant",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders": {
+            "post": {
+                "description": "Places a new order in any known status. Stock for every line item that refers to a known item is reserved while the order has not shipped, deducted if it is created already shipped, and left alone if it is created cancelled; an unknown order_status is rejected with error_param. Records the buyer's invoice request when one is given, with a warning if its tax ID is invalid for the shop's region. A voucher_code applies one of the shop's ongoing vouchers and uses it up once; shopee_voucher_amount is taken off as a voucher funded by Shopee. Both lower total_amount and show in the escrow detail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create order",
+                "parameters": [
+                    {
+                        "description": "Order to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created order",
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateOrderResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders/{order_sn}/invoice": {
+            "put": {
+                "description": "Records the invoice the buyer asked for on an order, as returned by get_buyer_invoice_info. Replaces any earlier request. A tax ID that is invalid for the order's region is still recorded, with a warning.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set buyer invoice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H\"",
+                        "description": "Order SN",
+                        "name": "order_sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invoice the buyer requested",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.BuyerInvoice"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recorded invoice",
+                        "schema": {
+                            "$ref": "#/definitions/main.SetInvoiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid invoice",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown order",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Withdraws the invoice request on an order, so get_buyer_invoice_info reports it as not requested",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete buyer invoice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H\"",
+                        "description": "Order SN",
+                        "name": "order_sn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Invoice request withdrawn"
+                    },
+                    "404": {
+                        "description": "Unknown order",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/proxy": {
+            "get": {
+                "description": "Returns the proxy mode, upstream and credentials. The partner key is never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get proxy config",
+                "responses": {
+                    "200": {
+                        "description": "Current settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.ProxyConfig"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Switches between off, record and replay mode and reloads the fixtures under fixture_dir. An empty partner_key keeps the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set proxy config",
+                "parameters": [
+                    {
+                        "description": "New settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ProxyConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.ProxyConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/proxy/fixtures": {
+            "get": {
+                "description": "Lists the request and response pairs recorded by the proxy, grouped by path",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List fixtures",
+                "responses": {
+                    "200": {
+                        "description": "Fixtures",
+                        "schema": {
+                            "$ref": "#/definitions/main.FixtureListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rate_limits": {
+            "get": {
+                "description": "Lists the QPS and daily quota limits applied to API requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List rate limits",
+                "responses": {
+                    "200": {
+                        "description": "Limits",
+                        "schema": {
+                            "$ref": "#/definitions/main.RateLimitListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a QPS and/or daily quota limit for matching partner, shop and path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add rate limit",
+                "parameters": [
+                    {
+                        "description": "Limit to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.RateLimit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Added limit",
+                        "schema": {
+                            "$ref": "#/definitions/main.RateLimit"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes every rate limit and counter",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear rate limits",
+                "responses": {
+                    "204": {
+                        "description": "Limits removed"
+                    }
+                }
+            }
+        },
+        "/admin/rate_limits/{id}": {
+            "put": {
+                "description": "Replaces an existing rate limit and resets its counters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update rate limit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Limit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New limit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.RateLimit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated limit",
+                        "schema": {
+                            "$ref": "#/definitions/main.RateLimit"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes one rate limit and its counters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete rate limit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Limit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Limit removed"
+                    },
+                    "404": {
+                        "description": "Unknown limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/regions": {
+            "get": {
+                "description": "Lists the markets shops can be created in, with the currency, price precision, address and phone format, payment methods and carriers their data uses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List region profiles",
+                "responses": {
+                    "200": {
+                        "description": "Region profiles",
+                        "schema": {
+                            "$ref": "#/definitions/main.RegionProfileListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/requests": {
+            "get": {
+                "description": "Lists the requests the mock received, oldest first, with their query, auth parameters, body, matched route and response status. Conditions combine with AND, so count answers questions like \"was ship_order called exactly once for this order\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"POST\"",
+                        "description": "HTTP method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"/api/v2/logistics/ship_order\"",
+                        "description": "Request path; may end in * to match a prefix",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"/admin/scenarios/:id\"",
+                        "description": "Matched route pattern",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 200,
+                        "description": "Response status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"123456\"",
+                        "description": "partner_id the request was made with",
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"789012\"",
+                        "description": "shop_id the request was made with",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1758274800,
+                        "description": "Earliest receive time, unix seconds",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1758278400,
+                        "description": "Latest receive time, unix seconds",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "order_sn_list:2404098R48U37H",
+                        "description": "Query parameter condition name:value; may repeat",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "pickup.address_id:123",
+                        "description": "JSON body condition dotted.path:value; may repeat",
+                        "name": "body",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.JournalListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Forgets every journalled request",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear requests",
+                "responses": {
+                    "204": {
+                        "description": "Journal cleared"
+                    }
+                }
+            }
+        },
+        "/admin/scenarios": {
+            "get": {
+                "description": "Lists every scenario run with the status of each step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List scenario runs",
+                "responses": {
+                    "200": {
+                        "description": "Runs",
+                        "schema": {
+                            "$ref": "#/definitions/main.ScenarioRunListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedules a scenario's steps on the virtual clock. Actions are create_order, pay, ship, cancel, mark_shipped, deliver, complete, request_cancel, request_return and set_status. Each step changes the stored order and fires the usual push messages when the clock reaches it. Stock moves with the order: shipping or completing a READY_TO_SHIP order deducts its reserved stock and starts its auto-complete timer, and cancelling releases it. Steps that would ship an order before it is ready, cancel a shipped order or move a cancelled one fail, as does a set_status to an unknown status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start scenario",
+                "parameters": [
+                    {
+                        "description": "Scenario to play",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.Scenario"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scheduled run",
+                        "schema": {
+                            "$ref": "#/definitions/main.ScenarioRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scenario",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/scenarios/{id}": {
+            "get": {
+                "description": "Returns one scenario run with the status of each step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get scenario run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Run",
+                        "schema": {
+                            "$ref": "#/definitions/main.ScenarioRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown run",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stops a scenario run; steps that have not played yet are skipped",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Cancel scenario run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Run",
+                        "schema": {
+                            "$ref": "#/definitions/main.ScenarioRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown run",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shops": {
+            "get": {
+                "description": "Lists every shop with its region, currency and merchant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List shops",
+                "responses": {
+                    "200": {
+                        "description": "Shops",
+                        "schema": {
+                            "$ref": "#/definitions/main.ShopListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a shop, optionally owned by an existing merchant. Its orders, items and responses are kept apart from every other shop's and follow its region's profile; currency defaults to the region's.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add shop",
+                "parameters": [
+                    {
+                        "description": "Shop to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.Shop"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Added shop",
+                        "schema": {
+                            "$ref": "#/definitions/main.Shop"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid shop",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown merchant",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stubs": {
+            "get": {
+                "description": "Lists the stub rules in the order they are tried, with how often each has matched",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List stub rules",
+                "responses": {
+                    "200": {
+                        "description": "Rules",
+                        "schema": {
+                            "$ref": "#/definitions/main.StubRuleListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a rule that answers matching API requests with a canned status and body, ahead of the built-in handlers. A status of 0 is treated as 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add stub rule",
+                "parameters": [
+                    {
+                        "description": "Rule to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.StubRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Added rule",
+                        "schema": {
+                            "$ref": "#/definitions/main.StubRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes every stub rule",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear stub rules",
+                "responses": {
+                    "204": {
+                        "description": "Rules removed"
+                    }
+                }
+            }
+        },
+        "/admin/stubs/{id}": {
+            "put": {
+                "description": "Replaces an existing stub rule, keeping its hit count",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update stub rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.StubRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated rule",
+                        "schema": {
+                            "$ref": "#/definitions/main.StubRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes one stub rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete stub rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Rule removed"
+                    },
+                    "404": {
+                        "description": "Unknown rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tokens": {
+            "post": {
+                "description": "Issues tokens for a shop, or for a merchant and all its shops, as if the seller had authorized the app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Issue access token",
+                "parameters": [
+                    {
+                        "description": "Shop or merchant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.IssueTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens",
+                        "schema": {
+                            "$ref": "#/definitions/main.AccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown shop or merchant",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook": {
+            "get": {
+                "description": "Returns the callback URL and retry settings used for push messages",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get webhook config",
+                "responses": {
+                    "200": {
+                        "description": "Current settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookConfig"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the callback URL and retry settings used for push messages. An empty callback_url disables pushes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set webhook config",
+                "parameters": [
+                    {
+                        "description": "New settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated settings",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook/deliveries": {
+            "get": {
+                "description": "Lists logged push messages with their payload, signature, response codes, latency and retry counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Push code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"failed\"",
+                        "description": "Delivery status: pending, delivered, failed or skipped",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1758274800,
+                        "description": "Earliest creation time, unix seconds",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1758278400,
+                        "description": "Latest creation time, unix seconds",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching deliveries, oldest first",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookDeliveryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook/deliveries/{id}": {
+            "get": {
+                "description": "Returns one logged push message with every delivery attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown delivery",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook/deliveries/{id}/redeliver": {
+            "post": {
+                "description": "Sends a logged push message again with the identical payload and waits for the consumer's answer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Redeliver webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1,
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery including the new attempt",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "No callback URL configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown delivery",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook/replay": {
+            "post": {
+                "description": "Sends every logged push message created in a time range again, in the original order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replay webhooks",
+                "parameters": [
+                    {
+                        "description": "Time range, unix seconds, and optional push code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookReplayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replayed deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/main.WebhookReplayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/auth/access_token/get": {
+            "post": {
+                "description": "Swaps a refresh token for a new access token with the same shops and merchants. Both old tokens stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.RefreshAccessTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens",
+                        "schema": {
+                            "$ref": "#/definitions/main.AccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.AccessTokenResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/auth/token/get": {
+            "post": {
+                "description": "Exchanges the code from shop authorization for an access token. Any non-empty code is accepted. A main_account_id gives a merchant token that acts for every shop the merchant owns.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get access token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Authorization code and shop or main account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GetAccessTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens",
+                        "schema": {
+                            "$ref": "#/definitions/main.AccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.AccessTokenResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/discount/add_discount": {
+            "post": {
+                "description": "Creates a discount that runs from start_time to end_time. It must start in the future and run for between an hour and 180 days.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discount"
+                ],
+                "summary": "Add discount",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Discount to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/discount/add_discount_item": {
+            "post": {
+                "description": "Puts items in an upcoming or ongoing discount at their promotion prices, replacing the price of items already in it. While the discount is ongoing, the items' current_price and the prices on new orders are the promotion prices. Items that cannot be added are listed in error_list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discount"
+                ],
+                "summary": "Add discount items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Discount and items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountItemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown discount",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddDiscountItemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/discount/end_discount": {
+            "post": {
+                "description": "Ends an ongoing discount now, putting its items back at their normal prices",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discount"
+                ],
+                "summary": "End discount",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Discount to end",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EndDiscountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndDiscountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndDiscountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown discount",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndDiscountResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/discount/get_discount": {
+            "get": {
+                "description": "Returns a discount, its status on the virtual clock and a page of its items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discount"
+                ],
+                "summary": "Get discount",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1000001,
+                        "description": "Discount ID",
+                        "name": "discount_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number, from 1",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 100,
+                        "description": "Items per page, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetDiscountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetDiscountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown discount",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetDiscountResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/discount/get_discount_list": {
+            "get": {
+                "description": "Lists the shop's discounts in a status on the virtual clock, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discount"
+                ],
+                "summary": "Get discount list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ongoing\"",
+                        "description": "upcoming, ongoing, expired or all",
+                        "name": "discount_status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number, from 1",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 100,
+                        "description": "Discounts per page, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetDiscountListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetDiscountListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/global_product/add_global_item": {
+            "post": {
+                "description": "Creates a global item owned by the merchant, validated against the catalogue like add_item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GlobalProduct"
+                ],
+                "summary": "Add global item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1001,
+                        "description": "Merchant ID",
+                        "name": "merchant_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Global item to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AddItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response; item_id is the global item ID",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Unknown merchant or token without access",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/global_product/get_global_item_info": {
+            "get": {
+                "description": "Retrieves global items owned by the merchant. Unknown IDs and other merchants' items are skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GlobalProduct"
+                ],
+                "summary": "Get global item info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"[100001]\"",
+                        "description": "Array of global item IDs",
+                        "name": "global_item_id_list",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1001,
+                        "description": "Merchant ID",
+                        "name": "merchant_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response; item_id is the global item ID",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetGlobalItemInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetGlobalItemInfoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Unknown merchant or token without access",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/logistics/ship_order": {
+            "post": {
+                "description": "Arranges pickup, dropoff or non-integrated shipment for an order and deducts its reserved stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logistics"
+                ],
+                "summary": "Ship order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Order to ship",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ShipOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.ShipOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ShipOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/media_space/complete_video_upload": {
+            "post": {
+                "description": "Joins the uploaded parts into the final video and verifies its size and MD5",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Complete video upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Parts to join",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CompleteVideoUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.MediaSpaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.MediaSpaceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/media_space/get_video_upload_result": {
+            "get": {
+                "description": "Reports whether a video upload succeeded and, if so, its video and thumbnail URLs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Get video upload result",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Video upload ID from init_video_upload",
+                        "name": "video_upload_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetVideoUploadResultResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown upload",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetVideoUploadResultResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/media_space/init_video_upload": {
+            "post": {
+                "description": "Starts a multi-part video upload for a file of the given size and MD5",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Init video upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID the upload is for",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Video file to upload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.InitVideoUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.InitVideoUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.InitVideoUploadResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/media_space/upload_image": {
+            "post": {
+                "description": "Stores a JPG or PNG image and returns its image_id and URL",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Upload image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID the upload is for",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file, at most 10 MB",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"normal\"",
+                        "description": "Upload scene, normal or desc",
+                        "name": "scene",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/media_space/upload_video_part": {
+            "post": {
+                "description": "Stores one part of a multi-part video upload, checking its MD5 when given",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Upload video part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Video upload ID from init_video_upload",
+                        "name": "video_upload_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Zero-based part sequence number",
+                        "name": "part_seq",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "MD5 of the part content",
+                        "name": "content_md5",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Part content, at most 4 MB",
+                        "name": "part_content",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.MediaSpaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.MediaSpaceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/merchant/get_shop_list_by_merchant": {
+            "get": {
+                "description": "Lists the shops owned by the merchant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Get shop list by merchant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1001,
+                        "description": "Merchant ID",
+                        "name": "merchant_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetShopListByMerchantResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Unknown merchant or token without access",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/cancel_order": {
+            "post": {
+                "description": "Cancels an order that has not shipped yet and releases its reserved stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Order to cancel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.CancelOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.CancelOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/download_invoice_doc": {
+            "get": {
+                "description": "Returns the invoice document last uploaded for an order",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Download invoice document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H\"",
+                        "description": "Order SN",
+                        "name": "order_sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invoice document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown order or no document uploaded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/get_buyer_invoice_info": {
+            "post": {
+                "description": "Retrieves the invoices buyers requested for up to 100 orders. Orders with no invoice request, or that are not the shop's, get is_requested false and an error instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get buyer invoice information",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with queries array",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetBuyerInvoiceInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/get_order_detail": {
+            "get": {
+                "description": "Retrieves detailed information about orders. Only order_sn, region, currency, cod, order_status, create_time, update_time, days_to_ship, ship_by_date, message_to_seller and reverse_shipping_fee are always returned; other fields, such as item_list, package_list, recipient_address, total_amount and buyer_username, only when named in response_optional_fields. Up to 50 order SNs per call; unknown SNs and other shops' orders are listed in not_found_order_sn_list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get order details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H,2404090000000X\"",
+                        "description": "Comma-separated list of order serial numbers",
+                        "name": "order_sn_list",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Return PENDING for orders whose payment is being verified, instead of UNPAID",
+                        "name": "request_order_status_pending",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"item_list,total_amount\"",
+                        "description": "Comma-separated optional fields to return",
+                        "name": "response_optional_fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response; only the required order fields are always present",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.OrderFieldsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "response": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/main.OrderFieldsListResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "order_list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/main.OrderDetail"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetOrderDetailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetOrderDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/set_note": {
+            "post": {
+                "description": "Replaces the seller's note on an order; get_order_detail returns it with its note_update_time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Set order note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Order and note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SetNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.SetNoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.SetNoteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/order/upload_invoice_doc": {
+            "post": {
+                "description": "Stores the invoice the seller issued for an order whose buyer requested one. Later get_buyer_invoice_info calls report it as uploaded, and download_invoice_doc returns it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Upload invoice document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H\"",
+                        "description": "Order SN",
+                        "name": "order_sn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "1 for PDF, 2 for JPG",
+                        "name": "file_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Invoice file, at most 5 MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadInvoiceDocResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request or no invoice requested",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadInvoiceDocResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown order",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadInvoiceDocResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/payment/get_escrow_detail": {
+            "get": {
+                "description": "Returns what the buyer paid for an order, the seller and Shopee discounts and vouchers, the fees, and the escrow amount paid out to the seller. Vouchers from the seller are deducted from the escrow; vouchers from Shopee are not. The mock charges a 5% commission on the merchandise after seller vouchers and a 2% transaction fee on the buyer's payment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get escrow detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2404098R48U37H\"",
+                        "description": "Order SN",
+                        "name": "order_sn",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetEscrowDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetEscrowDetailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown order",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetEscrowDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/add_item": {
+            "post": {
+                "description": "Creates a new item after validating its category, attributes and brand against the catalogue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Item to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AddItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/get_attributes": {
+            "get": {
+                "description": "Retrieves the attributes, value lists and units that items in a category may use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get attributes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 14646,
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"en\"",
+                        "description": "Display language",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetAttributesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetAttributesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/get_brand_list": {
+            "get": {
+                "description": "Retrieves a page of the brands items in a category may use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get brand list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 14646,
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Brand status, 1 for normal and 2 for pending",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Page size, at most 100",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetBrandListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetBrandListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/get_category": {
+            "get": {
+                "description": "Retrieves every category in the catalogue, with parent links and leaf flags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"en\"",
+                        "description": "Display language",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetCategoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/get_item_base_info": {
+            "get": {
+                "description": "Retrieves detailed information about products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get item base information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"[34001,34002]\"",
+                        "description": "Array of item IDs",
+                        "name": "item_id_list",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Include tax info in response",
+                        "name": "need_tax_info",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Include complaint policy in response",
+                        "name": "need_complaint_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetItemBaseInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetItemBaseInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/product/update_item": {
+            "post": {
+                "description": "Updates the given fields of an item and revalidates it against the catalogue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ItemWriteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sellerchat/get_conversation_list": {
+            "get": {
+                "description": "Lists the shop's conversations with buyers, most recent first. With direction older, next_timestamp_nano and next_conversation_id page back to the conversations after that cursor; with latest, forward to those before it. Conversations whose last messages share a timestamp are ordered by conversation ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SellerChat"
+                ],
+                "summary": "Get conversation list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"older\"",
+                        "description": "latest or older",
+                        "name": "direction",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"all\"",
+                        "description": "all, pinned or unread",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1758274838000000000,
+                        "description": "Cursor from the previous page's next_message_time_nano",
+                        "name": "next_timestamp_nano",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1000000001,
+                        "description": "Cursor from the previous page's conversation_id",
+                        "name": "next_conversation_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 25,
+                        "description": "Conversations per page, at most 60",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetConversationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetConversationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sellerchat/get_message": {
+            "get": {
+                "description": "Lists a conversation's messages, newest first. Pass the previous page's next_offset as offset to page back to older messages.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SellerChat"
+                ],
+                "summary": "Get messages",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1000000001,
+                        "description": "Conversation ID",
+                        "name": "conversation_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2000000000000000001\"",
+                        "description": "Message ID to page back from",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 25,
+                        "description": "Messages per page, at most 60",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown conversation",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetMessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sellerchat/read_conversation": {
+            "post": {
+                "description": "Marks a conversation read up to a message; buyer messages after it stay unread",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SellerChat"
+                ],
+                "summary": "Read conversation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Conversation and last read message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ReadConversationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.ReadConversationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ReadConversationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown conversation",
+                        "schema": {
+                            "$ref": "#/definitions/main.ReadConversationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sellerchat/send_message": {
+            "post": {
+                "description": "Sends a text, image, sticker, item or order message to a buyer the shop has a conversation with or an order from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SellerChat"
+                ],
+                "summary": "Send message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Message to send",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.SendMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.SendMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown buyer, item or order",
+                        "schema": {
+                            "$ref": "#/definitions/main.SendMessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sellerchat/upload_image": {
+            "post": {
+                "description": "Stores a JPG or PNG image and returns the URL to send as an image message",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SellerChat"
+                ],
+                "summary": "Upload chat image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file, at most 10 MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadChatImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.UploadChatImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/shop/get_shop_info": {
+            "get": {
+                "description": "Returns the name, region and status of the shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shop"
+                ],
+                "summary": "Get shop info",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetShopInfoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Unknown shop or token without access",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/voucher/add_voucher": {
+            "post": {
+                "description": "Creates a shop voucher (voucher_type 1) or a voucher for the items in item_id_list (voucher_type 2), taking a fixed discount_amount (reward_type 1) or a percentage capped at max_price (reward_type 2) off baskets of at least min_basket_price. It must start in the future and run for between an hour and 180 days, and its code must differ from the shop's other unexpired vouchers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Voucher"
+                ],
+                "summary": "Add voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Voucher to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AddVoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddVoucherResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddVoucherResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown item",
+                        "schema": {
+                            "$ref": "#/definitions/main.AddVoucherResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/voucher/end_voucher": {
+            "post": {
+                "description": "Ends an ongoing voucher now, so new orders can no longer use it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Voucher"
+                ],
+                "summary": "End voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Voucher to end",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EndVoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndVoucherResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndVoucherResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown voucher",
+                        "schema": {
+                            "$ref": "#/definitions/main.EndVoucherResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/voucher/get_voucher_list": {
+            "get": {
+                "description": "Lists the shop's vouchers in a status on the virtual clock, newest first, with how often each has been used",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Voucher"
+                ],
+                "summary": "Get voucher list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ongoing\"",
+                        "description": "upcoming, ongoing, expired or all",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number, from 1",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Vouchers per page, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetVoucherListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.GetVoucherListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/voucher/update_voucher": {
+            "post": {
+                "description": "Changes the fields given. An upcoming voucher can change anything; an ongoing one only its voucher_name, usage_quantity and a future end_time. Expired vouchers cannot change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Voucher"
+                ],
+                "summary": "Update voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 123456,
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 789012,
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "example": 1640995200,
+                        "description": "Request timestamp",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"your_access_token\"",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ABCD1234567890EFGH\"",
+                        "description": "Signature",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Voucher and the fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateVoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateVoucherResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateVoucherResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown voucher",
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateVoucherResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/images/{image_id}": {
+            "get": {
+                "description": "Serves an image previously uploaded through upload_image",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Serve image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown image",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/media/videos/{video_upload_id}": {
+            "get": {
+                "description": "Serves a video whose multi-part upload has succeeded",
+                "produces": [
+                    "video/mp4"
+                ],
+                "tags": [
+                    "MediaSpace"
+                ],
+                "summary": "Serve video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video upload ID",
+                        "name": "video_upload_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Video",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown video",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.AccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d"
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "expire_in": {
+                    "type": "integer",
+                    "example": 14400
+                },
+                "merchant_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1001
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "4b6f7c8d9e0a1b2c3d4e5f6a7b8c9d0e"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "shop_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        789012
+                    ]
+                }
+            }
+        },
+        "main.AddDiscountItem": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "item_promotion_price": {
+                    "type": "number",
+                    "example": 99
+                },
+                "purchase_limit": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "main.AddDiscountItemRequest": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.AddDiscountItem"
+                    }
+                }
+            }
+        },
+        "main.AddDiscountItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.AddDiscountItemResult"
+                }
+            }
+        },
+        "main.AddDiscountItemResult": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                },
+                "error_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.DiscountItemError"
+                    }
+                }
+            }
+        },
+        "main.AddDiscountRequest": {
+            "type": "object",
+            "properties": {
+                "discount_name": {
+                    "type": "string",
+                    "example": "Payday sale"
+                },
+                "end_time": {
+                    "type": "integer",
+                    "example": 1758884400
+                },
+                "start_time": {
+                    "type": "integer",
+                    "example": 1758279600
+                }
+            }
+        },
+        "main.AddDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.DiscountIDResult"
+                }
+            }
+        },
+        "main.AddItemRequest": {
+            "type": "object",
+            "properties": {
+                "attribute_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Attribute"
+                    }
+                },
+                "brand": {
+                    "$ref": "#/definitions/main.Brand"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 14646
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "NEW"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "first product 001first product"
+                },
+                "dimension": {
+                    "$ref": "#/definitions/main.Dimension"
+                },
+                "image": {
+                    "$ref": "#/definitions/main.ItemImage"
+                },
+                "item_name": {
+                    "type": "string",
+                    "example": "seller discount"
+                },
+                "item_sku": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "item_status": {
+                    "type": "string",
+                    "example": "NORMAL"
+                },
+                "original_price": {
+                    "type": "number",
+                    "example": 122.02
+                },
+                "seller_stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.StockLocation"
+                    }
+                },
+                "video_upload_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"
+                    ]
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 10.02
+                }
+            }
+        },
+        "main.AddVoucherRequest": {
+            "type": "object",
+            "properties": {
+                "discount_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "end_time": {
+                    "type": "integer",
+                    "example": 1758884400
+                },
+                "item_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_price": {
+                    "type": "number",
+                    "example": 50
+                },
+                "min_basket_price": {
+                    "type": "number",
+                    "example": 200
+                },
+                "percentage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "reward_type": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_time": {
+                    "type": "integer",
+                    "example": 1758279600
+                },
+                "usage_quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "voucher_code": {
+                    "type": "string",
+                    "example": "PAY10"
+                },
+                "voucher_name": {
+                    "type": "string",
+                    "example": "Payday voucher"
+                },
+                "voucher_type": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "main.AddVoucherResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.VoucherIDResult"
+                }
+            }
+        },
+        "main.AddressBreakdown": {
+            "type": "object",
+            "properties": {
+                "additional_info": {
+                    "type": "string",
+                    "example": ""
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Warszawa"
+                },
+                "detailed_address": {
+                    "type": "string",
+                    "example": "Ordona 7B Warszawa"
+                },
+                "district": {
+                    "type": "string",
+                    "example": ""
+                },
+                "full_address": {
+                    "type": "string",
+                    "example": "Ordona 7B Warszawa, Warszawa, 51120"
+                },
+                "postcode": {
+                    "type": "string",
+                    "example": "51120"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Poland"
+                },
+                "state": {
+                    "type": "string",
+                    "example": ""
+                },
+                "town": {
+                    "type": "string",
+                    "example": "Warszawa"
+                }
+            }
+        },
+        "main.Attribute": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer",
+                    "example": 4811
+                },
+                "attribute_value_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.AttributeValue"
+                    }
+                },
+                "is_mandatory": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "original_attribute_name": {
+                    "type": "string",
+                    "example": "Brand: L2 Default [14644]"
+                }
+            }
+        },
+        "main.AttributeListResponse": {
+            "type": "object",
+            "properties": {
+                "attribute_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueAttribute"
+                    }
+                }
+            }
+        },
+        "main.AttributeValue": {
+            "type": "object",
+            "properties": {
+                "original_value_name": {
+                    "type": "string",
+                    "example": "Default"
+                },
+                "value_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "value_unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "main.Brand": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "original_brand_name": {
+                    "type": "string",
+                    "example": "nike"
+                }
+            }
+        },
+        "main.BrandListResponse": {
+            "type": "object",
+            "properties": {
+                "brand_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueBrand"
+                    }
+                },
+                "has_next_page": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "input_type": {
+                    "type": "string",
+                    "example": "DROP_DOWN"
+                },
+                "is_mandatory": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_offset": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "main.BuyerInvoice": {
+            "type": "object",
+            "properties": {
+                "invoice_detail": {
+                    "$ref": "#/definitions/main.InvoiceDetail"
+                },
+                "invoice_type": {
+                    "type": "string",
+                    "example": "company"
+                }
+            }
+        },
+        "main.CancelOrderItem": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "model_id": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "main.CancelOrderRequest": {
+            "type": "object",
+            "properties": {
+                "cancel_reason": {
+                    "type": "string",
+                    "example": "OUT_OF_STOCK"
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CancelOrderItem"
+                    }
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                }
+            }
+        },
+        "main.CancelOrderResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.CancelOrderResult"
+                }
+            }
+        },
+        "main.CancelOrderResult": {
+            "type": "object",
+            "properties": {
+                "update_time": {
+                    "type": "integer",
+                    "example": 1758274838
+                }
+            }
+        },
+        "main.Catalogue": {
+            "type": "object",
+            "properties": {
+                "category_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueCategory"
+                    }
+                }
+            }
+        },
+        "main.CatalogueAttribute": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer",
+                    "example": 4811
+                },
+                "attribute_unit": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "g",
+                        "kg"
+                    ]
+                },
+                "attribute_value_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueAttributeValue"
+                    }
+                },
+                "display_attribute_name": {
+                    "type": "string",
+                    "example": "Brand: L2 Default [14644]"
+                },
+                "format_type": {
+                    "type": "string",
+                    "example": "NORMAL"
+                },
+                "input_type": {
+                    "type": "string",
+                    "example": "COMBO_BOX"
+                },
+                "input_validation_type": {
+                    "type": "string",
+                    "example": "STRING_TYPE"
+                },
+                "is_mandatory": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "original_attribute_name": {
+                    "type": "string",
+                    "example": "Brand: L2 Default [14644]"
+                }
+            }
+        },
+        "main.CatalogueAttributeValue": {
+            "type": "object",
+            "properties": {
+                "display_value_name": {
+                    "type": "string",
+                    "example": "Default"
+                },
+                "original_value_name": {
+                    "type": "string",
+                    "example": "Default"
+                },
+                "value_id": {
+                    "type": "integer",
+                    "example": 61
+                },
+                "value_unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "main.CatalogueBrand": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "display_brand_name": {
+                    "type": "string",
+                    "example": "Nike"
+                },
+                "original_brand_name": {
+                    "type": "string",
+                    "example": "nike"
+                }
+            }
+        },
+        "main.CatalogueCategory": {
+            "type": "object",
+            "properties": {
+                "attribute_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueAttribute"
+                    }
+                },
+                "brand_is_mandatory": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "brand_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CatalogueBrand"
+                    }
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 14646
+                },
+                "display_category_name": {
+                    "type": "string",
+                    "example": "Default L3"
+                },
+                "original_category_name": {
+                    "type": "string",
+                    "example": "Default L3"
+                },
+                "parent_category_id": {
+                    "type": "integer",
+                    "example": 14644
+                }
+            }
+        },
+        "main.Category": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 14646
+                },
+                "display_category_name": {
+                    "type": "string",
+                    "example": "Default L3"
+                },
+                "has_children": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "original_category_name": {
+                    "type": "string",
+                    "example": "Default L3"
+                },
+                "parent_category_id": {
+                    "type": "integer",
+                    "example": 14644
+                }
+            }
+        },
+        "main.CategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "category_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Category"
+                    }
+                }
+            }
+        },
+        "main.ChatImageResult": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "thumbnail": {
+                    "type": "string",
+                    "example": "http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "main.ChatMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "$ref": "#/definitions/main.ChatMessageContent"
+                },
+                "conversation_id": {
+                    "type": "string",
+                    "example": "1000000001"
+                },
+                "created_timestamp": {
+                    "type": "integer",
+                    "example": 1758274838
+                },
+                "from_id": {
+                    "type": "integer",
+                    "example": 121144654
+                },
+                "from_shop_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message_id": {
+                    "type": "string",
+                    "example": "2000000000000000001"
+                },
+                "message_type": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "TH"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "api"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "normal"
+                },
+                "to_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "to_shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.ChatMessageContent": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "sticker_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "sticker_package_id": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Hi, is this still available?"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"
+                }
+            }
+        },
+        "main.ClockAdvanceRequest": {
+            "type": "object",
+            "properties": {
+                "seconds": {
+                    "type": "integer",
+                    "example": 604800
+                }
+            }
+        },
+        "main.ClockSetRequest": {
+            "type": "object",
+            "properties": {
+                "frozen": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "now": {
+                    "type": "integer",
+                    "example": 1758274838
+                }
+            }
+        },
+        "main.ClockState": {
+            "type": "object",
+            "properties": {
+                "frozen": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "now": {
+                    "type": "integer",
+                    "example": 1758274838
+                },
+                "now_ms": {
+                    "type": "integer",
+                    "example": 1758274838123
+                },
+                "offset_seconds": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "pending_timers": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "main.ComplaintPolicy": {
+            "type": "object",
+            "properties": {
+                "additional_information": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "complaint_address_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "exclude_entrepreneur_warranty": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "warranty_time": {
+                    "type": "string",
+                    "example": "ONE_YEAR"
+                }
+            }
+        },
+        "main.CompleteVideoUploadReport": {
+            "type": "object",
+            "properties": {
+                "upload_cost": {
+                    "type": "integer",
+                    "example": 11832
+                }
+            }
+        },
+        "main.CompleteVideoUploadRequest": {
+            "type": "object",
+            "properties": {
+                "part_seq_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        1
+                    ]
+                },
+                "report_data": {
+                    "$ref": "#/definitions/main.CompleteVideoUploadReport"
+                },
+                "video_upload_id": {
+                    "type": "string",
+                    "example": "th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"
+                }
+            }
+        },
+        "main.Conversation": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string",
+                    "example": "1000000001"
+                },
+                "last_message_timestamp": {
+                    "type": "integer",
+                    "example": 1758274838000000000
+                },
+                "last_read_message_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "latest_message_content": {
+                    "$ref": "#/definitions/main.ChatMessageContent"
+                },
+                "latest_message_from_id": {
+                    "type": "integer",
+                    "example": 121144654
+                },
+                "latest_message_id": {
+                    "type": "string",
+                    "example": "2000000000000000001"
+                },
+                "latest_message_type": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "pinned": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "to_avatar": {
+                    "type": "string",
+                    "example": ""
+                },
+                "to_id": {
+                    "type": "integer",
+                    "example": 121144654
+                },
+                "to_name": {
+                    "type": "string",
+                    "example": "konlawatkkk"
+                },
+                "unread_count": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "main.ConversationListResult": {
+            "type": "object",
+            "properties": {
+                "conversations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Conversation"
+                    }
+                },
+                "page_result": {
+                    "$ref": "#/definitions/main.ConversationPageResult"
+                }
+            }
+        },
+        "main.ConversationPageCursor": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string",
+                    "example": "1000000001"
+                },
+                "next_message_time_nano": {
+                    "type": "string",
+                    "example": "1758274838000000000"
+                }
+            }
+        },
+        "main.ConversationPageResult": {
+            "type": "object",
+            "properties": {
+                "more": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_cursor": {
+                    "$ref": "#/definitions/main.ConversationPageCursor"
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "main.CreateOrderItem": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "model_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "main.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "invoice": {
+                    "$ref": "#/definitions/main.BuyerInvoice"
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CreateOrderItem"
+                    }
+                },
+                "message_to_seller": {
+                    "type": "string",
+                    "example": "Please pack carefully"
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "READY_TO_SHIP"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "shopee_voucher_amount": {
+                    "type": "number",
+                    "example": 10
+                },
+                "voucher_code": {
+                    "type": "string",
+                    "example": "PAY10"
+                }
+            }
+        },
+        "main.CreateOrderResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.OrderDetail"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.DescriptionField": {
+            "type": "object",
+            "properties": {
+                "field_type": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "image_info": {
+                    "$ref": "#/definitions/main.DescriptionFieldImage"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "-"
+                }
+            }
+        },
+        "main.DescriptionFieldImage": {
+            "type": "object",
+            "properties": {
+                "image_id": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "-"
+                }
+            }
+        },
+        "main.DescriptionInfo": {
+            "type": "object",
+            "properties": {
+                "extended_description": {
+                    "$ref": "#/definitions/main.ExtendedDescription"
+                }
+            }
+        },
+        "main.Dimension": {
+            "type": "object",
+            "properties": {
+                "package_height": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "package_length": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "package_width": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "main.DiscountDetailResult": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                },
+                "discount_name": {
+                    "type": "string",
+                    "example": "Payday sale"
+                },
+                "end_time": {
+                    "type": "integer",
+                    "example": 1758884400
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.DiscountItem"
+                    }
+                },
+                "more": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_time": {
+                    "type": "integer",
+                    "example": 1758279600
+                },
+                "status": {
+                    "type": "string",
+                    "example": "upcoming"
+                }
+            }
+        },
+        "main.DiscountIDResult": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                }
+            }
+        },
+        "main.DiscountItem": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "item_name": {
+                    "type": "string",
+                    "example": "Mock Item"
+                },
+                "item_original_price": {
+                    "type": "number",
+                    "example": 122.02
+                },
+                "item_promotion_price": {
+                    "type": "number",
+                    "example": 99
+                },
+                "purchase_limit": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "main.DiscountItemError": {
+            "type": "object",
+            "properties": {
+                "fail_error": {
+                    "type": "string",
+                    "example": "error_param"
+                },
+                "fail_message": {
+                    "type": "string",
+                    "example": "item_promotion_price must be below the item's original price"
+                },
+                "item_id": {
+                    "type": "integer",
+                    "example": 34002
+                }
+            }
+        },
+        "main.DiscountListResult": {
+            "type": "object",
+            "properties": {
+                "discount_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.DiscountSummary"
+                    }
+                },
+                "more": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "main.DiscountSummary": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                },
+                "discount_name": {
+                    "type": "string",
+                    "example": "Payday sale"
+                },
+                "end_time": {
+                    "type": "integer",
+                    "example": 1758884400
+                },
+                "start_time": {
+                    "type": "integer",
+                    "example": 1758279600
+                },
+                "status": {
+                    "type": "string",
+                    "example": "upcoming"
+                }
+            }
+        },
+        "main.EndDiscountRequest": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                }
+            }
+        },
+        "main.EndDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.EndDiscountResult"
+                }
+            }
+        },
+        "main.EndDiscountResult": {
+            "type": "object",
+            "properties": {
+                "discount_id": {
+                    "type": "integer",
+                    "example": 1000001
+                },
+                "modify_time": {
+                    "type": "integer",
+                    "example": 1758274838
+                }
+            }
+        },
+        "main.EndVoucherRequest": {
+            "type": "object",
+            "properties": {
+                "voucher_id": {
+                    "type": "integer",
+                    "example": 2000001
+                }
+            }
+        },
+        "main.EndVoucherResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.VoucherIDResult"
+                }
+            }
+        },
+        "main.EscrowDetail": {
+            "type": "object",
+            "properties": {
+                "buyer_user_name": {
+                    "type": "string",
+                    "example": "konlawatkkk"
+                },
+                "order_income": {
+                    "$ref": "#/definitions/main.OrderIncome"
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                }
+            }
+        },
+        "main.ExtendedDescription": {
+            "type": "object",
+            "properties": {
+                "field_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.DescriptionField"
+                    }
+                }
+            }
+        },
+        "main.FaultRule": {
+            "type": "object",
+            "properties": {
+                "fault": {
+                    "type": "string",
+                    "example": "shopee_error"
+                },
+                "http_status": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latency_distribution": {
+                    "type": "string",
+                    "example": "uniform"
+                },
+                "latency_max_ms": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "latency_stddev_ms": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Internal server error, please try again later"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v2/order/*"
+                },
+                "probability": {
+                    "type": "number",
+                    "example": 0.2
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "shopee_error": {
+                    "type": "string",
+                    "example": "error_server"
+                },
+                "timeout_ms": {
+                    "type": "integer",
+                    "example": 30000
+                }
+            }
+        },
+        "main.FaultRuleListResponse": {
+            "type": "object",
+            "properties": {
+                "rule_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.FaultRule"
+                    }
+                }
+            }
+        },
+        "main.Fixture": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "application/json"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "3f0c1a9b5e7d2c48"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v2/order/get_order_detail"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "order_sn_list=2404098R48U37H\u0026shop_id=789012"
+                },
+                "recorded_at": {
+                    "type": "integer",
+                    "example": 1758274838
+                },
+                "request_body": {
+                    "type": "object"
+                },
+                "response_body": {
+                    "type": "object"
+                },
+                "response_text": {
+                    "type": "string",
+                    "example": ""
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "main.FixtureListResponse": {
+            "type": "object",
+            "properties": {
+                "fixture_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Fixture"
+                    }
+                }
+            }
+        },
+        "main.GenerateDataRequest": {
+            "type": "object",
+            "properties": {
+                "cod_ratio": {
+                    "type": "number",
+                    "example": 0.4
+                },
+                "items": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "now": {
+                    "type": "integer",
+                    "example": 1758274838
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "voucher_ratio": {
+                    "type": "number",
+                    "example": 0.2
+                }
+            }
+        },
+        "main.GenerateDataResponse": {
+            "type": "object",
+            "properties": {
+                "first_item_id": {
+                    "type": "integer",
+                    "example": 34003
+                },
+                "item_count": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "skipped_orders": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status_count": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "main.GetAccessTokenRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "6d4a6b7c8e9f0a1b2c3d4e5f6a7b8c9d"
+                },
+                "main_account_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "partner_id": {
+                    "type": "integer",
+                    "example": 123456
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.GetAttributesResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.AttributeListResponse"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.GetBrandListResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.BrandListResponse"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.GetBuyerInvoiceInfoRequest": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "your_access_token"
+                },
+                "partner_id": {
+                    "type": "integer",
+                    "example": 123456
+                },
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.QueryItem"
+                    }
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "sign": {
+                    "type": "string",
+                    "example": "ABCD1234567890EFGH"
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1640995200
+                }
+            }
+        },
+        "main.GetBuyerInvoiceInfoResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "invoice_info_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.InvoiceInfo"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                }
+            }
+        },
+        "main.GetCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.CategoryListResponse"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.GetConversationListResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.ConversationListResult"
+                }
+            }
+        },
+        "main.GetDiscountListResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.DiscountListResult"
+                }
+            }
+        },
+        "main.GetDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.DiscountDetailResult"
+                }
+            }
+        },
+        "main.GetEscrowDetailResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.EscrowDetail"
+                }
+            }
+        },
+        "main.GetGlobalItemInfoResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.GlobalItemListResponse"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.GetItemBaseInfoResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.ItemListResponse"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.GetMessageResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.MessageListResult"
+                }
+            }
+        },
+        "main.GetOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "023c50ace933ba38473a5fb2a7dc8821"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.OrderListResponse"
+                }
+            }
+        },
+        "main.GetShopInfoResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "is_cb": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1001
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "region": {
+                    "type": "string",
+                    "example": "TH"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "shop_name": {
+                    "type": "string",
+                    "example": "Mock Shop TH"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "NORMAL"
+                }
+            }
+        },
+        "main.GetShopListByMerchantResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "more": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "shop_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.MerchantShop"
+                    }
+                }
+            }
+        },
+        "main.GetVideoUploadResultResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.VideoUploadResult"
+                }
+            }
+        },
+        "main.GetVoucherListResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.VoucherListResult"
+                }
+            }
+        },
+        "main.GlobalItemListResponse": {
+            "type": "object",
+            "properties": {
+                "global_item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ItemDetail"
+                    }
+                }
+            }
+        },
+        "main.ImageInfo": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cf.shopee.vn/file/vn-11134207-7qukw-lf6guphtf6oad3_tn"
+                }
+            }
+        },
+        "main.ImageURL": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string",
+                    "example": "http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"
+                },
+                "image_url_region": {
+                    "type": "string",
+                    "example": "TH"
+                }
+            }
+        },
+        "main.InitVideoUploadRequest": {
+            "type": "object",
+            "properties": {
+                "file_md5": {
+                    "type": "string",
+                    "example": "2a8ac3c8c4b1d1e16a9d2c7f1b4ef5a1"
+                },
+                "file_size": {
+                    "type": "integer",
+                    "example": 1261924
+                }
+            }
+        },
+        "main.InitVideoUploadResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.InitVideoUploadResult"
+                }
+            }
+        },
+        "main.InitVideoUploadResult": {
+            "type": "object",
+            "properties": {
+                "video_upload_id": {
+                    "type": "string",
+                    "example": "th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"
+                }
+            }
+        },
+        "main.InjectChatMessageRequest": {
+            "type": "object",
+            "properties": {
+                "buyer_user_id": {
+                    "type": "integer",
+                    "example": 121144654
+                },
+                "buyer_username": {
+                    "type": "string",
+                    "example": "konlawatkkk"
+                },
+                "content": {
+                    "$ref": "#/definitions/main.SendMessageContent"
+                },
+                "message_type": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.InvoiceDetail": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Ordona 7B Warszawa, Warszawa, 51120"
+                },
+                "address_breakdown": {
+                    "$ref": "#/definitions/main.AddressBreakdown"
+                },
+                "branch_tax_id": {
+                    "type": "string",
+                    "example": "00001"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "testing.just@op.pl"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kevin Yap"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "0886761062"
+                },
+                "tax_id": {
+                    "type": "string",
+                    "example": "0745561010054"
+                }
+            }
+        },
+        "main.InvoiceInfo": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "invoice_detail": {
+                    "$ref": "#/definitions/main.InvoiceDetail"
+                },
+                "invoice_type": {
+                    "type": "string",
+                    "example": "personal"
+                },
+                "is_requested": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_uploaded": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2209160VNPKXF7"
+                },
+                "upload_time": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "main.InvoiceSettings": {
+            "type": "object",
+            "properties": {
+                "invalid_tax_ids": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "main.IssueTokenRequest": {
+            "type": "object",
+            "properties": {
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.ItemDetail": {
+            "type": "object",
+            "properties": {
+                "attribute_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Attribute"
+                    }
+                },
+                "brand": {
+                    "$ref": "#/definitions/main.Brand"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 14646
+                },
+                "complaint_policy": {
+                    "$ref": "#/definitions/main.ComplaintPolicy"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "NEW/USED"
+                },
+                "create_time": {
+                    "type": "integer",
+                    "example": 1600572637
+                },
+                "deboost": {
+                    "type": "string",
+                    "example": "false"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "first product 001first product"
+                },
+                "description_info": {
+                    "$ref": "#/definitions/main.DescriptionInfo"
+                },
+                "description_type": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "dimension": {
+                    "$ref": "#/definitions/main.Dimension"
+                },
+                "has_model": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "image": {
+                    "$ref": "#/definitions/main.ItemImage"
+                },
+                "item_dangerous": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "item_id": {
+                    "type": "integer",
+                    "example": 34002
+                },
+                "item_name": {
+                    "type": "string",
+                    "example": "seller discount"
+                },
+                "item_sku": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "item_status": {
+                    "type": "string",
+                    "example": "NORMAL"
+                },
+                "logistic_info": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.LogisticInfo"
+                    }
+                },
+                "pre_order": {
+                    "$ref": "#/definitions/main.PreOrder"
+                },
+                "price_info": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.PriceInfo"
+                    }
+                },
+                "promotion_id": {
+                    "type": "integer",
+                    "example": 13123
+                },
+                "size_chart": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "stock_info_v2": {
+                    "$ref": "#/definitions/main.StockInfoV2"
+                },
+                "tax_info": {
+                    "$ref": "#/definitions/main.TaxInfo"
+                },
+                "update_time": {
+                    "type": "integer",
+                    "example": 1600572640
+                },
+                "video_info": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.VideoInfo"
+                    }
+                },
+                "weight": {
+                    "type": "string",
+                    "example": "10.02"
+                },
+                "wholesales": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Wholesale"
+                    }
+                }
+            }
+        },
+        "main.ItemImage": {
+            "type": "object",
+            "properties": {
+                "image_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "-"
+                    ]
+                },
+                "image_url_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "-"
+                    ]
+                }
+            }
+        },
+        "main.ItemListResponse": {
+            "type": "object",
+            "properties": {
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ItemDetail"
+                    }
+                }
+            }
+        },
+        "main.ItemWriteResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.ItemDetail"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.JournalEntry": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "$ref": "#/definitions/main.RequestAuth"
+                },
+                "body": {
+                    "type": "object"
+                },
+                "body_size": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "body_text": {
+                    "type": "string",
+                    "example": ""
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/json"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "method": {
+                    "type": "string",
+                    "example": "POST"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v2/logistics/ship_order"
+                },
+                "query": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "received_at": {
+                    "type": "integer",
+                    "example": 1758274838
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/v2/logistics/ship_order"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "main.JournalListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "request_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.JournalEntry"
+                    }
+                }
+            }
+        },
+        "main.LogisticInfo": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "estimated_shipping_fee": {
+                    "type": "number",
+                    "example": 4.02
+                },
+                "is_free": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "logistic_id": {
+                    "type": "integer",
+                    "example": 80012
+                },
+                "logistic_name": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "shipping_fee": {
+                    "type": "number",
+                    "example": 5.02
+                },
+                "size_id": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "main.MediaSpaceResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": ""
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                }
+            }
+        },
+        "main.Merchant": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "THB"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1001
+                },
+                "merchant_name": {
+                    "type": "string",
+                    "example": "Mock Merchant"
+                },
+                "shop_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        789012
+                    ]
+                }
+            }
+        },
+        "main.MerchantListResponse": {
+            "type": "object",
+            "properties": {
+                "merchant_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Merchant"
+                    }
+                }
+            }
+        },
+        "main.MerchantShop": {
+            "type": "object",
+            "properties": {
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.MessageListResult": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ChatMessage"
+                    }
+                },
+                "page_result": {
+                    "$ref": "#/definitions/main.MessagePageResult"
+                }
+            }
+        },
+        "main.MessagePageResult": {
+            "type": "object",
+            "properties": {
+                "next_offset": {
+                    "type": "string",
+                    "example": ""
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "main.OrderDetail": {
+            "type": "object",
+            "required": [
+                "cod",
+                "create_time",
+                "currency",
+                "days_to_ship",
+                "message_to_seller",
+                "order_sn",
+                "order_status",
+                "region",
+                "reverse_shipping_fee",
+                "ship_by_date",
+                "update_time"
+            ],
+            "properties": {
+                "actual_shipping_fee_confirmed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "buyer_cancel_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "buyer_cpf_id": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "buyer_user_id": {
+                    "type": "integer",
+                    "example": 1170319091
+                },
+                "buyer_username": {
+                    "type": "string",
+                    "example": "xt4fdsf96j"
+                },
+                "cancel_by": {
+                    "type": "string",
+                    "example": ""
+                },
+                "cancel_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "cod": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "create_time": {
+                    "type": "integer",
+                    "example": 1712601591
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "VND"
+                },
+                "days_to_ship": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "dropshipper": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "dropshipper_phone": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "estimated_shipping_fee": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "fulfillment_flag": {
+                    "type": "string",
+                    "example": "fulfilled_by_local_seller"
+                },
+                "goods_to_declare": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "invoice_data": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.OrderItem"
+                    }
+                },
+                "message_to_seller": {
+                    "type": "string",
+                    "example": ""
+                },
+                "note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "note_update_time": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "order_sn": {
+                    "type": "string",
+                    "example": "2404098R48U37H"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "COMPLETED"
+                },
+                "package_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.PackageDetail"
+                    }
+                },
+                "pay_time": {
+                    "type": "integer",
+                    "example": 1712817766
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "Cash on Delivery"
+                },
+                "pickup_done_time": {
+                    "type": "integer",
+                    "example": 1712726577
+                },
+                "recipient_address": {
+                    "$ref": "#/definitions/main.RecipientAddress"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "VN"
+                },
+                "reverse_shipping_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "ship_by_date": {
+                    "type": "integer",
+                    "example": 1712671200
+                },
+                "shipping_carrier": {
+                    "type": "string",
+                    "example": "Thunder Express"
+                },
+                "split_up": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 32119
+                },
+                "update_time": {
+                    "type": "integer",
+                    "example": 1713139948
+                }
+            }
+        },
+        "main.OrderFieldsListResponse": {
+            "type": "object",
+            "properties": {
+                "not_found_order_sn_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "order_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "main.OrderFieldsResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.OrderFieldsListResponse"
+                }
+            }
+        },
+        "main.OrderIncome": {
+            "type": "object",
+            "properties": {
+                "buyer_paid_shipping_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "buyer_total_amount": {
+                    "type": "number",
+                    "example": 112.02
+                },
+                "coins": {
+                    "type": "number",
+                    "example": 0
+                },
+                "commission_fee": {
+                    "type": "number",
+                    "example": 6.1
+                },
+                "escrow_amount": {
+                    "type": "number",
+                    "example": 102.15
+                },
+                "estimated_shipping_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "original_price": {
+                    "type": "number",
+                    "example": 122.02
+                },
+                "seller_discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "seller_transaction_fee": {
+                    "type": "number",
+                    "example": 2.24
+                },
+                "seller_voucher_code": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "service_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "shopee_discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "voucher_from_seller": {
+                    "type": "number",
+                    "example": 0
+                },
+                "voucher_from_shopee": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "main.OrderItem": {
+            "type": "object",
+            "properties": {
+                "add_on_deal": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "add_on_deal_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "image_info": {
+                    "$ref": "#/definitions/main.ImageInfo"
+                },
+                "is_b2c_owned_item": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_prescription_item": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "item_id": {
+                    "type": "integer",
+                    "example": 23620853561
+                },
+                "item_name": {
+                    "type": "string",
+                    "example": "Minecraft NFA"
+                },
+                "item_sku": {
+                    "type": "string",
+                    "example": ""
+                },
+                "main_item": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "model_discounted_price": {
+                    "type": "number",
+                    "example": 48000
+                },
+                "model_id": {
+                    "type": "integer",
+                    "example": 221404189791
+                },
+                "model_name": {
+                    "type": "string",
+                    "example": "60g（Papaya）"
+                },
+                "model_original_price": {
+                    "type": "number",
+                    "example": 300000
+                },
+                "model_quantity_purchased": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "model_sku": {
+                    "type": "string",
+                    "example": "QAZ-SADOER-05"
+                },
+                "order_item_id": {
+                    "type": "integer",
+                    "example": 23620853561
+                },
+                "product_location_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VN10XX2UZ"
+                    ]
+                },
+                "promotion_group_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "promotion_id": {
+                    "type": "integer",
+                    "example": 779222207758537
+                },
+                "promotion_type": {
+                    "type": "string",
+                    "example": "flash_sale"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 0.01
+                },
+                "wholesale": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "main.OrderListResponse": {
+            "type": "object",
+            "properties": {
+                "not_found_order_sn_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2404090000000X"
+                    ]
+                },
+                "order_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.OrderDetail"
+                    }
+                }
+            }
+        },
+        "main.PackageDetail": {
+            "type": "object",
+            "properties": {
+                "allow_self_design_awb": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "group_shipment_id": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "item_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.PackageItemDetail"
+                    }
+                },
+                "logistics_channel_id": {
+                    "type": "integer",
+                    "example": 18080
+                },
+                "logistics_status": {
+                    "type": "string",
+                    "example": "LOGISTICS_DELIVERY_DONE"
+                },
+                "package_number": {
+                    "type": "string",
+                    "example": "OFG166300791210964"
+                },
+                "parcel_chargeable_weight_gram": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "shipping_carrier": {
+                    "type": "string",
+                    "example": "5-Day Delivery (SPX)"
+                },
+                "sorting_group": {
+                    "type": "string",
+                    "example": "North"
+                }
+            }
+        },
+        "main.PackageItemDetail": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 23620853561
+                },
+                "model_id": {
+                    "type": "integer",
+                    "example": 221404189791
+                },
+                "model_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "order_item_id": {
+                    "type": "integer",
+                    "example": 23620853561
+                },
+                "product_location_id": {
+                    "type": "string",
+                    "example": "VN10XX2UZ"
+                },
+                "promotion_group_id": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "main.PreOrder": {
             "type": "object",
             "properties": {
-                "additional_info": {
-                    "type": "string",
-                    "example": ""
+                "days_to_ship": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "city": {
+                "is_pre_order": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "main.PriceInfo": {
+            "type": "object",
+            "properties": {
+                "currency": {
                     "type": "string",
-                    "example": "Warszawa"
+                    "example": "SGD"
                 },
-                "detailed_address": {
-                    "type": "string",
-                    "example": "Ordona 7B Warszawa"
+                "current_price": {
+                    "type": "number",
+                    "example": 122.02
                 },
-                "district": {
-                    "type": "string",
-                    "example": ""
+                "inflated_price_of_current_price": {
+                    "type": "number",
+                    "example": 111.02
                 },
-                "full_address": {
+                "inflated_price_of_original_price": {
+                    "type": "number",
+                    "example": 222.02
+                },
+                "original_price": {
+                    "type": "number",
+                    "example": 122.02
+                },
+                "sip_item_price": {
+                    "type": "number",
+                    "example": 100.02
+                },
+                "sip_item_price_source": {
                     "type": "string",
-                    "example": "Ordona 7B Warszawa, Warszawa, 51120"
+                    "example": "auto"
+                }
+            }
+        },
+        "main.ProxyConfig": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "your_access_token"
                 },
-                "postcode": {
+                "fixture_dir": {
                     "type": "string",
-                    "example": "51120"
+                    "example": "fixtures"
                 },
-                "region": {
+                "mode": {
                     "type": "string",
-                    "example": "Poland"
+                    "example": "record"
                 },
-                "state": {
+                "partner_id": {
+                    "type": "integer",
+                    "example": 123456
+                },
+                "partner_key": {
                     "type": "string",
-                    "example": ""
+                    "example": "your_partner_key_here"
                 },
-                "town": {
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "upstream_url": {
                     "type": "string",
-                    "example": "Warszawa"
+                    "example": "https://partner.test-stable.shopeemobile.com"
                 }
             }
         },
-        "main.Attribute": {
+        "main.QueryItem": {
             "type": "object",
             "properties": {
-                "attribute_id": {
+                "order_sn": {
+                    "type": "string",
+                    "example": "220314U0G6UNMN"
+                }
+            }
+        },
+        "main.RateLimit": {
+            "type": "object",
+            "properties": {
+                "daily_quota": {
                     "type": "integer",
-                    "example": 4811
+                    "example": 100000
                 },
-                "attribute_value_list": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "partner_id": {
+                    "type": "integer",
+                    "example": 123456
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v2/order/*"
+                },
+                "qps": {
+                    "type": "number",
+                    "example": 10
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "path"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                }
+            }
+        },
+        "main.RateLimitListResponse": {
+            "type": "object",
+            "properties": {
+                "rate_limit_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.AttributeValue"
+                        "$ref": "#/definitions/main.RateLimit"
                     }
+                }
+            }
+        },
+        "main.ReadConversationRequest": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "integer",
+                    "example": 1000000001
                 },
-                "is_mandatory": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "original_attribute_name": {
+                "last_read_message_id": {
                     "type": "string",
-                    "example": "Brand: L2 Default [14644]"
+                    "example": "2000000000000000001"
                 }
             }
         },
-        "main.AttributeValue": {
+        "main.ReadConversationResponse": {
             "type": "object",
             "properties": {
-                "original_value_name": {
+                "error": {
                     "type": "string",
-                    "example": "Default"
+                    "example": ""
                 },
-                "value_id": {
-                    "type": "integer",
-                    "example": 0
+                "message": {
+                    "type": "string",
+                    "example": ""
                 },
-                "value_unit": {
+                "request_id": {
                     "type": "string",
-                    "example": "g"
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.ReadConversationResult"
                 }
             }
         },
-        "main.Brand": {
+        "main.ReadConversationResult": {
             "type": "object",
             "properties": {
-                "brand_id": {
-                    "type": "integer",
-                    "example": 123
-                },
-                "original_brand_name": {
+                "conversation_id": {
                     "type": "string",
-                    "example": "nike"
+                    "example": "1000000001"
+                },
+                "unread_count": {
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
-        "main.ComplaintPolicy": {
+        "main.RecipientAddress": {
             "type": "object",
             "properties": {
-                "additional_information": {
+                "city": {
                     "type": "string",
-                    "example": "-"
+                    "example": "มะกอก"
                 },
-                "complaint_address_id": {
+                "district": {
+                    "type": "string",
+                    "example": "แม่แรง"
+                },
+                "full_address": {
+                    "type": "string",
+                    "example": "บ้าน******"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "P******n"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "******64"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "VN"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "Bạc Liêu"
+                },
+                "town": {
+                    "type": "string",
+                    "example": ""
+                },
+                "zipcode": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
+        "main.RefreshAccessTokenRequest": {
+            "type": "object",
+            "properties": {
+                "merchant_id": {
                     "type": "integer",
                     "example": 0
                 },
-                "exclude_entrepreneur_warranty": {
-                    "type": "boolean",
-                    "example": true
+                "partner_id": {
+                    "type": "integer",
+                    "example": 123456
                 },
-                "warranty_time": {
+                "refresh_token": {
                     "type": "string",
-                    "example": "ONE_YEAR"
+                    "example": "4b6f7c8d9e0a1b2c3d4e5f6a7b8c9d0e"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
                 }
             }
         },
-        "main.DescriptionField": {
+        "main.RegionProfile": {
             "type": "object",
             "properties": {
-                "field_type": {
+                "address": {
+                    "$ref": "#/definitions/main.AddressBreakdown"
+                },
+                "buyer_name": {
                     "type": "string",
-                    "example": "-"
+                    "example": "กลวัชร หัสไทรทอง"
                 },
-                "image_info": {
-                    "$ref": "#/definitions/main.DescriptionFieldImage"
+                "buyer_phone": {
+                    "type": "string",
+                    "example": "0909573314"
                 },
-                "text": {
+                "carriers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Kerry Express",
+                        "Flash Express"
+                    ]
+                },
+                "country": {
                     "type": "string",
-                    "example": "-"
+                    "example": "Thailand"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "THB"
+                },
+                "payment_methods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Cash on Delivery",
+                        "Credit Card/Debit Card"
+                    ]
+                },
+                "phone_code": {
+                    "type": "string",
+                    "example": "66"
+                },
+                "price_decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "region": {
+                    "type": "string",
+                    "example": "TH"
+                },
+                "thb_rate": {
+                    "type": "number",
+                    "example": 1
+                },
+                "tracking_prefix": {
+                    "type": "string",
+                    "example": "TH"
+                }
+            }
+        },
+        "main.RegionProfileListResponse": {
+            "type": "object",
+            "properties": {
+                "region_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.RegionProfile"
+                    }
                 }
             }
         },
-        "main.DescriptionFieldImage": {
+        "main.RequestAuth": {
             "type": "object",
             "properties": {
-                "image_id": {
+                "access_token": {
                     "type": "string",
-                    "example": "-"
+                    "example": "your_access_token"
                 },
-                "image_url": {
+                "partner_id": {
                     "type": "string",
-                    "example": "-"
+                    "example": "123456"
+                },
+                "shop_id": {
+                    "type": "string",
+                    "example": "789012"
+                },
+                "sign": {
+                    "type": "string",
+                    "example": "ABCD1234567890EFGH"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "1640995200"
                 }
             }
         },
-        "main.DescriptionInfo": {
+        "main.Scenario": {
             "type": "object",
             "properties": {
-                "extended_description": {
-                    "$ref": "#/definitions/main.ExtendedDescription"
+                "name": {
+                    "type": "string",
+                    "example": "paid, shipped, returned"
+                },
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ScenarioStep"
+                    }
                 }
             }
         },
-        "main.Dimension": {
+        "main.ScenarioRun": {
             "type": "object",
             "properties": {
-                "package_height": {
+                "id": {
                     "type": "integer",
-                    "example": 13
+                    "example": 1
                 },
-                "package_length": {
+                "name": {
+                    "type": "string",
+                    "example": "paid, shipped, returned"
+                },
+                "shop_id": {
                     "type": "integer",
-                    "example": 11
+                    "example": 789012
                 },
-                "package_width": {
+                "start": {
                     "type": "integer",
-                    "example": 12
+                    "example": 1758274838
+                },
+                "status": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ScenarioStepResult"
+                    }
                 }
             }
         },
-        "main.ExtendedDescription": {
+        "main.ScenarioRunListResponse": {
             "type": "object",
             "properties": {
-                "field_list": {
+                "run_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.DescriptionField"
+                        "$ref": "#/definitions/main.ScenarioRun"
                     }
                 }
             }
         },
-        "main.GetBuyerInvoiceInfoRequest": {
+        "main.ScenarioStep": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "action": {
                     "type": "string",
-                    "example": "your_access_token"
+                    "example": "pay"
                 },
-                "partner_id": {
-                    "type": "integer",
-                    "example": 123456
+                "at": {
+                    "type": "string",
+                    "example": "+5m"
                 },
-                "queries": {
+                "cancel_by": {
+                    "type": "string",
+                    "example": "buyer"
+                },
+                "item_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.QueryItem"
+                        "$ref": "#/definitions/main.CreateOrderItem"
                     }
                 },
-                "shop_id": {
-                    "type": "integer",
-                    "example": 789012
+                "message_to_seller": {
+                    "type": "string",
+                    "example": "Please pack carefully"
                 },
-                "sign": {
+                "order_sn": {
                     "type": "string",
-                    "example": "ABCD1234567890EFGH"
+                    "example": "2404098R48U37H"
                 },
-                "timestamp": {
-                    "type": "integer",
-                    "example": 1640995200
+                "order_status": {
+                    "type": "string",
+                    "example": "UNPAID"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "CHANGE_OF_MIND"
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "example": "TH0123456789"
                 }
             }
         },
-        "main.GetBuyerInvoiceInfoResponse": {
+        "main.ScenarioStepResult": {
             "type": "object",
             "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "pay"
+                },
+                "at": {
+                    "type": "string",
+                    "example": "+5m"
+                },
+                "cancel_by": {
+                    "type": "string",
+                    "example": "buyer"
+                },
+                "due_time": {
+                    "type": "integer",
+                    "example": 1758275138
+                },
                 "error": {
                     "type": "string",
                     "example": ""
                 },
-                "invoice_info_list": {
+                "item_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.InvoiceInfo"
+                        "$ref": "#/definitions/main.CreateOrderItem"
                     }
                 },
-                "message": {
+                "message_to_seller": {
                     "type": "string",
-                    "example": ""
+                    "example": "Please pack carefully"
                 },
-                "request_id": {
+                "order_sn": {
                     "type": "string",
-                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                    "example": "2404098R48U37H"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "UNPAID"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "CHANGE_OF_MIND"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "done"
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "example": "TH0123456789"
                 }
             }
         },
-        "main.GetItemBaseInfoResponse": {
+        "main.SendMessageContent": {
             "type": "object",
             "properties": {
-                "error": {
+                "image_url": {
                     "type": "string",
-                    "example": "-"
+                    "example": "http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"
                 },
-                "message": {
+                "item_id": {
+                    "type": "integer",
+                    "example": 34001
+                },
+                "order_sn": {
                     "type": "string",
-                    "example": "-"
+                    "example": "2404098R48U37H"
                 },
-                "request_id": {
+                "sticker_id": {
                     "type": "string",
-                    "example": "7b9da0c6926642199c33ee9dd3a266f5"
+                    "example": "1"
                 },
-                "response": {
-                    "$ref": "#/definitions/main.ItemListResponse"
+                "sticker_package_id": {
+                    "type": "string",
+                    "example": "1000"
                 },
-                "warning": {
+                "text": {
                     "type": "string",
-                    "example": "-"
+                    "example": "Your parcel ships today"
                 }
             }
         },
-        "main.GetOrderDetailResponse": {
+        "main.SendMessageRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "$ref": "#/definitions/main.SendMessageContent"
+                },
+                "message_type": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "to_id": {
+                    "type": "integer",
+                    "example": 121144654
+                }
+            }
+        },
+        "main.SendMessageResponse": {
             "type": "object",
             "properties": {
                 "error": {
@@ -581,793 +8918,847 @@ const docTemplate = `{
                 },
                 "request_id": {
                     "type": "string",
-                    "example": "023c50ace933ba38473a5fb2a7dc8821"
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
                 },
                 "response": {
-                    "$ref": "#/definitions/main.OrderListResponse"
+                    "$ref": "#/definitions/main.ChatMessage"
                 }
             }
         },
-        "main.ImageInfo": {
+        "main.SetInvoiceResponse": {
             "type": "object",
             "properties": {
-                "image_url": {
+                "invoice_detail": {
+                    "$ref": "#/definitions/main.InvoiceDetail"
+                },
+                "invoice_type": {
                     "type": "string",
-                    "example": "https://cf.shopee.vn/file/vn-11134207-7qukw-lf6guphtf6oad3_tn"
+                    "example": "company"
+                },
+                "warning": {
+                    "type": "string",
+                    "example": "invalid tax_id check digit for TH"
                 }
             }
         },
-        "main.InvoiceDetail": {
+        "main.SetNoteRequest": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "Ordona 7B Warszawa, Warszawa, 51120"
-                },
-                "address_breakdown": {
-                    "$ref": "#/definitions/main.AddressBreakdown"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "testing.just@op.pl"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Kevin Yap"
-                },
-                "phone_number": {
+                "note": {
                     "type": "string",
-                    "example": ""
+                    "example": "Gift wrap requested by phone"
                 },
-                "tax_id": {
+                "order_sn": {
                     "type": "string",
-                    "example": "0745561010054"
+                    "example": "2404098R48U37H"
                 }
             }
         },
-        "main.InvoiceInfo": {
+        "main.SetNoteResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
                     "example": ""
                 },
-                "invoice_detail": {
-                    "$ref": "#/definitions/main.InvoiceDetail"
-                },
-                "invoice_type": {
+                "message": {
                     "type": "string",
-                    "example": "personal"
-                },
-                "is_requested": {
-                    "type": "boolean",
-                    "example": false
+                    "example": ""
                 },
-                "order_sn": {
+                "request_id": {
                     "type": "string",
-                    "example": "2209160VNPKXF7"
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
                 }
             }
         },
-        "main.ItemDetail": {
+        "main.ShipOrderDropoff": {
             "type": "object",
             "properties": {
-                "attribute_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.Attribute"
-                    }
-                },
-                "brand": {
-                    "$ref": "#/definitions/main.Brand"
-                },
-                "category_id": {
-                    "type": "integer",
-                    "example": 14646
-                },
-                "complaint_policy": {
-                    "$ref": "#/definitions/main.ComplaintPolicy"
-                },
-                "condition": {
-                    "type": "string",
-                    "example": "NEW/USED"
-                },
-                "create_time": {
+                "branch_id": {
                     "type": "integer",
-                    "example": 1600572637
+                    "example": 0
                 },
-                "deboost": {
+                "sender_real_name": {
                     "type": "string",
-                    "example": "false"
+                    "example": ""
                 },
-                "description": {
+                "tracking_number": {
                     "type": "string",
-                    "example": "first product 001first product"
-                },
-                "description_info": {
-                    "$ref": "#/definitions/main.DescriptionInfo"
-                },
-                "description_type": {
+                    "example": ""
+                }
+            }
+        },
+        "main.ShipOrderNonIntegrated": {
+            "type": "object",
+            "properties": {
+                "tracking_number": {
                     "type": "string",
-                    "example": "-"
-                },
-                "dimension": {
-                    "$ref": "#/definitions/main.Dimension"
-                },
-                "has_model": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "image": {
-                    "$ref": "#/definitions/main.ItemImage"
-                },
-                "item_dangerous": {
+                    "example": "TH0123456789"
+                }
+            }
+        },
+        "main.ShipOrderPickup": {
+            "type": "object",
+            "properties": {
+                "address_id": {
                     "type": "integer",
-                    "example": 0
+                    "example": 123
                 },
-                "item_id": {
-                    "type": "integer",
-                    "example": 34002
+                "pickup_time_id": {
+                    "type": "string",
+                    "example": "1758333600"
                 },
-                "item_name": {
+                "tracking_number": {
                     "type": "string",
-                    "example": "seller discount"
+                    "example": ""
+                }
+            }
+        },
+        "main.ShipOrderRequest": {
+            "type": "object",
+            "properties": {
+                "dropoff": {
+                    "$ref": "#/definitions/main.ShipOrderDropoff"
                 },
-                "item_sku": {
+                "non_integrated": {
+                    "$ref": "#/definitions/main.ShipOrderNonIntegrated"
+                },
+                "order_sn": {
                     "type": "string",
-                    "example": "-"
+                    "example": "2404098R48U37H"
                 },
-                "item_status": {
+                "package_number": {
                     "type": "string",
-                    "example": "NORMAL"
+                    "example": "OFG166300791210964"
                 },
-                "logistic_info": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.LogisticInfo"
-                    }
+                "pickup": {
+                    "$ref": "#/definitions/main.ShipOrderPickup"
+                }
+            }
+        },
+        "main.ShipOrderResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
                 },
-                "pre_order": {
-                    "$ref": "#/definitions/main.PreOrder"
+                "message": {
+                    "type": "string",
+                    "example": ""
                 },
-                "price_info": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.PriceInfo"
-                    }
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                }
+            }
+        },
+        "main.Shop": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "THB"
                 },
-                "promotion_id": {
+                "merchant_id": {
                     "type": "integer",
-                    "example": 13123
+                    "example": 1001
                 },
-                "size_chart": {
+                "region": {
                     "type": "string",
-                    "example": "-"
-                },
-                "stock_info_v2": {
-                    "$ref": "#/definitions/main.StockInfoV2"
-                },
-                "tax_info": {
-                    "$ref": "#/definitions/main.TaxInfo"
+                    "example": "TH"
                 },
-                "update_time": {
+                "shop_id": {
                     "type": "integer",
-                    "example": 1600572640
-                },
-                "video_info": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.VideoInfo"
-                    }
+                    "example": 789012
                 },
-                "weight": {
+                "shop_name": {
                     "type": "string",
-                    "example": "10.02"
+                    "example": "Mock Shop TH"
                 },
-                "wholesales": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.Wholesale"
-                    }
+                "status": {
+                    "type": "string",
+                    "example": "NORMAL"
                 }
             }
         },
-        "main.ItemImage": {
+        "main.ShopListResponse": {
             "type": "object",
             "properties": {
-                "image_id_list": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "-"
-                    ]
-                },
-                "image_url_list": {
+                "shop_list": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "-"
-                    ]
+                        "$ref": "#/definitions/main.Shop"
+                    }
                 }
             }
         },
-        "main.ItemListResponse": {
+        "main.StockInfoV2": {
             "type": "object",
             "properties": {
-                "item_list": {
+                "seller_stock": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.ItemDetail"
+                        "$ref": "#/definitions/main.StockLocation"
+                    }
+                },
+                "shopee_stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.StockLocation"
                     }
+                },
+                "summary_info": {
+                    "$ref": "#/definitions/main.SummaryInfo"
                 }
             }
         },
-        "main.LogisticInfo": {
+        "main.StockLocation": {
             "type": "object",
             "properties": {
-                "enabled": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "estimated_shipping_fee": {
-                    "type": "number",
-                    "example": 4.02
-                },
-                "is_free": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "logistic_id": {
-                    "type": "integer",
-                    "example": 80012
-                },
-                "logistic_name": {
+                "location_id": {
                     "type": "string",
                     "example": "-"
                 },
-                "shipping_fee": {
-                    "type": "number",
-                    "example": 5.02
-                },
-                "size_id": {
+                "stock": {
                     "type": "integer",
-                    "example": 0
+                    "example": 10
                 }
             }
         },
-        "main.OrderDetail": {
+        "main.StubRule": {
             "type": "object",
             "properties": {
-                "actual_shipping_fee_confirmed": {
-                    "type": "boolean",
-                    "example": true
+                "body": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "buyer_cancel_reason": {
+                "content_type": {
                     "type": "string",
-                    "example": ""
+                    "example": "application/json"
                 },
-                "buyer_cpf_id": {
-                    "type": "string",
-                    "example": "null"
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "buyer_user_id": {
+                "hit_count": {
                     "type": "integer",
-                    "example": 1170319091
+                    "example": 0
                 },
-                "buyer_username": {
-                    "type": "string",
-                    "example": "xt4fdsf96j"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "cancel_by": {
+                "method": {
                     "type": "string",
-                    "example": ""
+                    "example": "POST"
                 },
-                "cancel_reason": {
+                "path": {
                     "type": "string",
-                    "example": ""
-                },
-                "cod": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "/api/v2/order/get_buyer_invoice_info"
                 },
-                "create_time": {
+                "priority": {
                     "type": "integer",
-                    "example": 1712601591
-                },
-                "currency": {
-                    "type": "string",
-                    "example": "VND"
+                    "example": 10
                 },
-                "days_to_ship": {
-                    "type": "integer",
-                    "example": 2
+                "query": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "dropshipper": {
-                    "type": "string",
-                    "example": "null"
+                "response_body": {
+                    "type": "object"
                 },
-                "dropshipper_phone": {
+                "response_text": {
                     "type": "string",
-                    "example": "null"
+                    "example": ""
                 },
-                "estimated_shipping_fee": {
+                "status": {
                     "type": "integer",
-                    "example": 5000
+                    "example": 200
+                }
+            }
+        },
+        "main.StubRuleListResponse": {
+            "type": "object",
+            "properties": {
+                "rule_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.StubRule"
+                    }
+                }
+            }
+        },
+        "main.SummaryInfo": {
+            "type": "object",
+            "properties": {
+                "total_available_stock": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "fulfillment_flag": {
+                "total_reserved_stock": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "main.TaxInfo": {
+            "type": "object",
+            "properties": {
+                "cest": {
                     "type": "string",
-                    "example": "fulfilled_by_local_seller"
-                },
-                "goods_to_declare": {
-                    "type": "boolean",
-                    "example": false
+                    "example": "-"
                 },
-                "invoice_data": {
+                "csosn": {
                     "type": "string",
-                    "example": "null"
+                    "example": "-"
                 },
-                "item_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.OrderItem"
-                    }
+                "diff_state_cfop": {
+                    "type": "string",
+                    "example": "-"
                 },
-                "message_to_seller": {
+                "hs_code": {
                     "type": "string",
-                    "example": ""
+                    "example": "-"
                 },
-                "note": {
+                "invoice_option": {
                     "type": "string",
-                    "example": ""
+                    "example": "-"
                 },
-                "note_update_time": {
-                    "type": "integer",
-                    "example": 0
+                "measure_unit": {
+                    "type": "string",
+                    "example": "-"
                 },
-                "order_sn": {
+                "ncm": {
                     "type": "string",
-                    "example": "2404098R48U37H"
+                    "example": "-"
                 },
-                "order_status": {
+                "origin": {
                     "type": "string",
-                    "example": "COMPLETED"
+                    "example": "-"
                 },
-                "package_list": {
+                "tax_code": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "vat_rate": {
+                    "type": "string",
+                    "example": "-"
+                }
+            }
+        },
+        "main.UpdateItemRequest": {
+            "type": "object",
+            "properties": {
+                "attribute_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.PackageDetail"
+                        "$ref": "#/definitions/main.Attribute"
                     }
                 },
-                "pay_time": {
+                "brand": {
+                    "$ref": "#/definitions/main.Brand"
+                },
+                "category_id": {
                     "type": "integer",
-                    "example": 1712817766
+                    "example": 14646
                 },
-                "payment_method": {
+                "condition": {
                     "type": "string",
-                    "example": "Cash on Delivery"
+                    "example": "NEW"
                 },
-                "pickup_done_time": {
-                    "type": "integer",
-                    "example": 1712726577
+                "description": {
+                    "type": "string",
+                    "example": "first product 001first product"
                 },
-                "recipient_address": {
-                    "$ref": "#/definitions/main.RecipientAddress"
+                "dimension": {
+                    "$ref": "#/definitions/main.Dimension"
                 },
-                "region": {
-                    "type": "string",
-                    "example": "VN"
+                "image": {
+                    "$ref": "#/definitions/main.ItemImage"
                 },
-                "reverse_shipping_fee": {
+                "item_id": {
                     "type": "integer",
-                    "example": 0
+                    "example": 34001
                 },
-                "ship_by_date": {
-                    "type": "integer",
-                    "example": 1712671200
+                "item_name": {
+                    "type": "string",
+                    "example": "seller discount"
                 },
-                "shipping_carrier": {
+                "item_sku": {
                     "type": "string",
-                    "example": "Thunder Express"
+                    "example": "-"
                 },
-                "split_up": {
-                    "type": "boolean",
-                    "example": false
+                "item_status": {
+                    "type": "string",
+                    "example": "NORMAL"
                 },
-                "total_amount": {
-                    "type": "integer",
-                    "example": 32119
+                "video_upload_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"
+                    ]
                 },
-                "update_time": {
-                    "type": "integer",
-                    "example": 1713139948
+                "weight": {
+                    "type": "number",
+                    "example": 10.02
                 }
             }
         },
-        "main.OrderItem": {
+        "main.UpdateVoucherRequest": {
             "type": "object",
             "properties": {
-                "add_on_deal": {
-                    "type": "boolean",
-                    "example": false
+                "discount_amount": {
+                    "type": "number",
+                    "example": 0
                 },
-                "add_on_deal_id": {
+                "end_time": {
                     "type": "integer",
-                    "example": 0
+                    "example": 1758970800
                 },
-                "image_info": {
-                    "$ref": "#/definitions/main.ImageInfo"
+                "item_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "is_b2c_owned_item": {
-                    "type": "boolean",
-                    "example": false
+                "max_price": {
+                    "type": "number",
+                    "example": 50
                 },
-                "is_prescription_item": {
-                    "type": "boolean",
-                    "example": false
+                "min_basket_price": {
+                    "type": "number",
+                    "example": 200
                 },
-                "item_id": {
+                "percentage": {
                     "type": "integer",
-                    "example": 23620853561
-                },
-                "item_name": {
-                    "type": "string",
-                    "example": "Minecraft NFA"
-                },
-                "item_sku": {
-                    "type": "string",
-                    "example": ""
+                    "example": 15
                 },
-                "main_item": {
-                    "type": "boolean",
-                    "example": false
+                "reward_type": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "model_discounted_price": {
+                "start_time": {
                     "type": "integer",
-                    "example": 48000
+                    "example": 1758279600
                 },
-                "model_id": {
+                "usage_quantity": {
                     "type": "integer",
-                    "example": 221404189791
+                    "example": 200
                 },
-                "model_name": {
+                "voucher_code": {
                     "type": "string",
-                    "example": "60g（Papaya）"
+                    "example": "PAY10"
                 },
-                "model_original_price": {
+                "voucher_id": {
                     "type": "integer",
-                    "example": 300000
+                    "example": 2000001
                 },
-                "model_quantity_purchased": {
-                    "type": "integer",
-                    "example": 1
+                "voucher_name": {
+                    "type": "string",
+                    "example": "Payday voucher"
+                }
+            }
+        },
+        "main.UpdateVoucherResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
                 },
-                "model_sku": {
+                "message": {
                     "type": "string",
-                    "example": "QAZ-SADOER-05"
+                    "example": ""
                 },
-                "order_item_id": {
-                    "type": "integer",
-                    "example": 23620853561
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
                 },
-                "product_location_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "VN10XX2UZ"
-                    ]
+                "response": {
+                    "$ref": "#/definitions/main.VoucherIDResult"
+                }
+            }
+        },
+        "main.UploadChatImageResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
                 },
-                "promotion_group_id": {
-                    "type": "integer",
-                    "example": 0
+                "message": {
+                    "type": "string",
+                    "example": ""
                 },
-                "promotion_id": {
-                    "type": "integer",
-                    "example": 779222207758537
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
                 },
-                "promotion_type": {
+                "response": {
+                    "$ref": "#/definitions/main.ChatImageResult"
+                }
+            }
+        },
+        "main.UploadImageResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string",
-                    "example": "flash_sale"
+                    "example": ""
                 },
-                "weight": {
-                    "type": "number",
-                    "example": 0.01
+                "message": {
+                    "type": "string",
+                    "example": ""
                 },
-                "wholesale": {
-                    "type": "boolean",
-                    "example": false
+                "request_id": {
+                    "type": "string",
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                },
+                "response": {
+                    "$ref": "#/definitions/main.UploadImageResult"
                 }
             }
         },
-        "main.OrderListResponse": {
+        "main.UploadImageResult": {
             "type": "object",
             "properties": {
-                "order_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.OrderDetail"
-                    }
+                "image_info": {
+                    "$ref": "#/definitions/main.UploadedImageInfo"
                 }
             }
         },
-        "main.PackageDetail": {
+        "main.UploadInvoiceDocResponse": {
             "type": "object",
             "properties": {
-                "allow_self_design_awb": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "group_shipment_id": {
+                "error": {
                     "type": "string",
-                    "example": "null"
-                },
-                "item_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.PackageItemDetail"
-                    }
-                },
-                "logistics_channel_id": {
-                    "type": "integer",
-                    "example": 18080
+                    "example": ""
                 },
-                "logistics_status": {
+                "message": {
                     "type": "string",
-                    "example": "LOGISTICS_DELIVERY_DONE"
+                    "example": ""
                 },
-                "package_number": {
+                "request_id": {
                     "type": "string",
-                    "example": "OFG166300791210964"
-                },
-                "parcel_chargeable_weight_gram": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "shipping_carrier": {
+                    "example": "a2c45ca2683caf1651ecab5a4d5942ce"
+                }
+            }
+        },
+        "main.UploadedImageInfo": {
+            "type": "object",
+            "properties": {
+                "image_id": {
                     "type": "string",
-                    "example": "5-Day Delivery (SPX)"
+                    "example": "th-11134201-7r98o-lxyz12ab34cd56"
                 },
-                "sorting_group": {
-                    "type": "string",
-                    "example": "North"
+                "image_url_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ImageURL"
+                    }
                 }
             }
         },
-        "main.PackageItemDetail": {
+        "main.UploadedVideoInfo": {
             "type": "object",
             "properties": {
-                "item_id": {
-                    "type": "integer",
-                    "example": 23620853561
-                },
-                "model_id": {
-                    "type": "integer",
-                    "example": 221404189791
-                },
-                "model_quantity": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "order_item_id": {
+                "duration": {
                     "type": "integer",
-                    "example": 23620853561
+                    "example": 0
                 },
-                "product_location_id": {
-                    "type": "string",
-                    "example": "VN10XX2UZ"
+                "thumbnail_url_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ImageURL"
+                    }
                 },
-                "promotion_group_id": {
-                    "type": "integer",
-                    "example": 0
+                "video_url_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.VideoURL"
+                    }
                 }
             }
         },
-        "main.PreOrder": {
+        "main.VideoInfo": {
             "type": "object",
             "properties": {
-                "days_to_ship": {
+                "duration": {
                     "type": "integer",
-                    "example": 3
+                    "example": 0
                 },
-                "is_pre_order": {
-                    "type": "boolean",
-                    "example": true
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "-"
+                },
+                "video_url": {
+                    "type": "string",
+                    "example": "-"
                 }
             }
         },
-        "main.PriceInfo": {
+        "main.VideoURL": {
             "type": "object",
             "properties": {
-                "currency": {
+                "video_url": {
                     "type": "string",
-                    "example": "SGD"
-                },
-                "current_price": {
-                    "type": "number",
-                    "example": 122.02
-                },
-                "inflated_price_of_current_price": {
-                    "type": "number",
-                    "example": 111.02
-                },
-                "inflated_price_of_original_price": {
-                    "type": "number",
-                    "example": 222.02
-                },
-                "original_price": {
-                    "type": "number",
-                    "example": 122.02
-                },
-                "sip_item_price": {
-                    "type": "number",
-                    "example": 100.02
+                    "example": "http://localhost:3001/media/videos/th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"
                 },
-                "sip_item_price_source": {
+                "video_url_region": {
                     "type": "string",
-                    "example": "auto"
+                    "example": "TH"
                 }
             }
         },
-        "main.QueryItem": {
+        "main.VideoUploadResult": {
             "type": "object",
             "properties": {
-                "order_sn": {
+                "message": {
                     "type": "string",
-                    "example": "220314U0G6UNMN"
+                    "example": ""
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SUCCEEDED"
+                },
+                "video_info": {
+                    "$ref": "#/definitions/main.UploadedVideoInfo"
                 }
             }
         },
-        "main.RecipientAddress": {
+        "main.Voucher": {
             "type": "object",
             "properties": {
-                "city": {
-                    "type": "string",
-                    "example": "มะกอก"
+                "current_usage": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "district": {
-                    "type": "string",
-                    "example": "แม่แรง"
+                "discount_amount": {
+                    "type": "number",
+                    "example": 0
                 },
-                "full_address": {
-                    "type": "string",
-                    "example": "บ้าน******"
+                "end_time": {
+                    "type": "integer",
+                    "example": 1758884400
                 },
-                "name": {
-                    "type": "string",
-                    "example": "P******n"
+                "item_id_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "******64"
+                "max_price": {
+                    "type": "number",
+                    "example": 50
                 },
-                "region": {
-                    "type": "string",
-                    "example": "VN"
+                "min_basket_price": {
+                    "type": "number",
+                    "example": 200
                 },
-                "state": {
+                "percentage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "reward_type": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_time": {
+                    "type": "integer",
+                    "example": 1758279600
+                },
+                "status": {
                     "type": "string",
-                    "example": "Bạc Liêu"
+                    "example": "ongoing"
                 },
-                "town": {
+                "usage_quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "voucher_code": {
                     "type": "string",
-                    "example": ""
+                    "example": "PAY10"
                 },
-                "zipcode": {
+                "voucher_id": {
+                    "type": "integer",
+                    "example": 2000001
+                },
+                "voucher_name": {
                     "type": "string",
-                    "example": ""
+                    "example": "Payday voucher"
+                },
+                "voucher_type": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "main.StockInfoV2": {
+        "main.VoucherIDResult": {
             "type": "object",
             "properties": {
-                "seller_stock": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.StockLocation"
-                    }
+                "voucher_id": {
+                    "type": "integer",
+                    "example": 2000001
+                }
+            }
+        },
+        "main.VoucherListResult": {
+            "type": "object",
+            "properties": {
+                "more": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "shopee_stock": {
+                "voucher_list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/main.StockLocation"
+                        "$ref": "#/definitions/main.Voucher"
                     }
-                },
-                "summary_info": {
-                    "$ref": "#/definitions/main.SummaryInfo"
                 }
             }
         },
-        "main.StockLocation": {
+        "main.WebhookAttempt": {
             "type": "object",
             "properties": {
-                "location_id": {
+                "callback_url": {
                     "type": "string",
-                    "example": "-"
+                    "example": "http://localhost:8080/shopee/push"
                 },
-                "stock": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "latency_ms": {
                     "type": "integer",
-                    "example": 10
+                    "example": 12
+                },
+                "redelivery": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "response_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "retry": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "sent_at": {
+                    "type": "integer",
+                    "example": 1758274838123
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "6d1f0c2a9e..."
                 }
             }
         },
-        "main.SummaryInfo": {
+        "main.WebhookConfig": {
             "type": "object",
             "properties": {
-                "total_available_stock": {
+                "callback_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/shopee/push"
+                },
+                "max_retries": {
                     "type": "integer",
-                    "example": 100
+                    "example": 3
                 },
-                "total_reserved_stock": {
+                "retry_base_delay_ms": {
                     "type": "integer",
-                    "example": 100
+                    "example": 1000
                 }
             }
         },
-        "main.TaxInfo": {
+        "main.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "cest": {
-                    "type": "string",
-                    "example": "-"
-                },
-                "csosn": {
-                    "type": "string",
-                    "example": "-"
-                },
-                "diff_state_cfop": {
-                    "type": "string",
-                    "example": "-"
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.WebhookAttempt"
+                    }
                 },
-                "hs_code": {
-                    "type": "string",
-                    "example": "-"
+                "code": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "invoice_option": {
-                    "type": "string",
-                    "example": "-"
+                "created_at": {
+                    "type": "integer",
+                    "example": 1758274838
                 },
-                "measure_unit": {
-                    "type": "string",
-                    "example": "-"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "ncm": {
-                    "type": "string",
-                    "example": "-"
+                "payload": {
+                    "type": "object"
                 },
-                "origin": {
-                    "type": "string",
-                    "example": "-"
+                "retry_count": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "tax_code": {
-                    "type": "string",
-                    "example": "-"
+                "shop_id": {
+                    "type": "integer",
+                    "example": 789012
                 },
-                "vat_rate": {
+                "status": {
                     "type": "string",
-                    "example": "-"
+                    "example": "delivered"
                 }
             }
         },
-        "main.VideoInfo": {
+        "main.WebhookDeliveryListResponse": {
             "type": "object",
             "properties": {
-                "duration": {
+                "delivery_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "main.WebhookReplayRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer",
-                    "example": 0
+                    "example": 3
                 },
-                "thumbnail_url": {
-                    "type": "string",
-                    "example": "-"
+                "from": {
+                    "type": "integer",
+                    "example": 1758274800
                 },
-                "video_url": {
-                    "type": "string",
-                    "example": "-"
+                "to": {
+                    "type": "integer",
+                    "example": 1758278400
+                }
+            }
+        },
+        "main.WebhookReplayResponse": {
+            "type": "object",
+            "properties": {
+                "delivery_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.WebhookDelivery"
+                    }
+                },
+                "replayed": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	faultNone        = ""
+	faultHTTPError   = "http_error"
+	faultTimeout     = "timeout"
+	faultReset       = "connection_reset"
+	faultShopeeError = "shopee_error"
+)
+
+var errFaultRuleNotFound = errors.New("fault rule not found")
+
+// FaultRule injects latency and, with the given probability, a fault into
+// requests whose path and shop match. Path may end in "*" to match a prefix;
+// an empty path or a shop_id of 0 matches everything. A rule without a
+// probability always fires; a probability of 0 turns it off.
+type FaultRule struct {
+	ID                  int64    `json:"id" example:"1"`
+	Path                string   `json:"path" example:"/api/v2/order/*"`
+	ShopID              int64    `json:"shop_id" example:"789012"`
+	Probability         *float64 `json:"probability" example:"0.2"`
+	LatencyDistribution string   `json:"latency_distribution" example:"uniform"`
+	LatencyMilli        int64    `json:"latency_ms" example:"200"`
+	LatencyMaxMilli     int64    `json:"latency_max_ms" example:"1500"`
+	LatencyStdDevMilli  int64    `json:"latency_stddev_ms" example:"0"`
+	Fault               string   `json:"fault" example:"shopee_error"`
+	HTTPStatus          int      `json:"http_status" example:"500"`
+	ShopeeError         string   `json:"shopee_error" example:"error_server"`
+	Message             string   `json:"message" example:"Internal server error, please try again later"`
+	TimeoutMilli        int64    `json:"timeout_ms" example:"30000"`
+}
+
+type FaultRuleListResponse struct {
+	RuleList []FaultRule `json:"rule_list"`
+}
+
+func (r FaultRule) matches(path string, shopID int64) bool {
+	if r.ShopID != 0 && r.ShopID != shopID {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return r.Path == "" || r.Path == path
+}
+
+func (r FaultRule) validate() error {
+	switch r.LatencyDistribution {
+	case "", "fixed", "uniform", "normal":
+	default:
+		return errors.New("latency_distribution must be fixed, uniform or normal")
+	}
+	switch r.Fault {
+	case faultNone, faultHTTPError, faultTimeout, faultReset:
+	case faultShopeeError:
+		if r.ShopeeError == "" {
+			return errors.New("shopee_error is required for the shopee_error fault")
+		}
+	default:
+		return errors.New("fault must be http_error, timeout, connection_reset or shopee_error")
+	}
+	if r.Probability != nil && (*r.Probability < 0 || *r.Probability > 1) {
+		return errors.New("probability must be between 0 and 1")
+	}
+	if r.LatencyMilli < 0 || r.LatencyMaxMilli < 0 || r.LatencyStdDevMilli < 0 || r.TimeoutMilli < 0 {
+		return errors.New("latency and timeout values must not be negative")
+	}
+	return nil
+}
+
+// setDefaults makes a rule without a probability always fire.
+func (r *FaultRule) setDefaults() {
+	if r.Probability == nil {
+		always := 1.0
+		r.Probability = &always
+	}
+}
+
+// latency draws a delay from the rule's latency distribution.
+func (r FaultRule) latency(rng *rand.Rand) time.Duration {
+	ms := float64(r.LatencyMilli)
+	switch r.LatencyDistribution {
+	case "uniform":
+		if r.LatencyMaxMilli > r.LatencyMilli {
+			ms += rng.Float64() * float64(r.LatencyMaxMilli-r.LatencyMilli)
+		}
+	case "normal":
+		ms += rng.NormFloat64() * float64(r.LatencyStdDevMilli)
+	}
+	if ms < 0 {
+		ms = 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// faultInjector holds the fault rules applied by the faultInjection
+// middleware. Rules are evaluated in the order they were added and the
+// first one that fires wins.
+type faultInjector struct {
+	mu     sync.Mutex
+	rules  []FaultRule
+	nextID int64
+	rng    *rand.Rand
+}
+
+var faults = &faultInjector{
+	nextID: 1,
+	rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
+func (f *faultInjector) list() []FaultRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FaultRule{}, f.rules...)
+}
+
+func (f *faultInjector) add(rule FaultRule) FaultRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rule.setDefaults()
+	rule.ID = f.nextID
+	f.nextID++
+	f.rules = append(f.rules, rule)
+	return rule
+}
+
+func (f *faultInjector) replace(id int64, rule FaultRule) (FaultRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.rules {
+		if f.rules[i].ID == id {
+			rule.ID = id
+			rule.setDefaults()
+			f.rules[i] = rule
+			return rule, nil
+		}
+	}
+	return FaultRule{}, errFaultRuleNotFound
+}
+
+func (f *faultInjector) remove(id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.rules {
+		if f.rules[i].ID == id {
+			f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errFaultRuleNotFound
+}
+
+func (f *faultInjector) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = nil
+}
+
+// pick returns the first matching rule whose probability roll succeeds,
+// along with the latency drawn for it.
+func (f *faultInjector) pick(path string, shopID int64) (FaultRule, time.Duration, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, rule := range f.rules {
+		if !rule.matches(path, shopID) || f.rng.Float64() >= *rule.Probability {
+			continue
+		}
+		return rule, rule.latency(f.rng), true
+	}
+	return FaultRule{}, 0, false
+}
+
+// faultInjection is a Fiber middleware that delays or fails API requests
+// according to the configured fault rules.
+func faultInjection(c *fiber.Ctx) error {
+	rule, latency, ok := faults.pick(c.Path(), requestShopID(c))
+	if !ok {
+		return c.Next()
+	}
+
+	time.Sleep(latency)
+
+	switch rule.Fault {
+	case faultHTTPError:
+		status := rule.HTTPStatus
+		if status == 0 {
+			status = 500
+		}
+		return c.Status(status).SendString(http.StatusText(status))
+	case faultTimeout:
+		timeout := time.Duration(rule.TimeoutMilli) * time.Millisecond
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+		time.Sleep(timeout)
+		return resetConnection(c)
+	case faultReset:
+		return resetConnection(c)
+	case faultShopeeError:
+		status := rule.HTTPStatus
+		if status == 0 {
+			status = 500
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"request_id": newRequestID(),
+			"error":      rule.ShopeeError,
+			"message":    rule.Message,
+		})
+	}
+	return c.Next()
+}
+
+// resetConnection closes the client connection without a response. The
+// connection is hijacked so fasthttp closes it instead of writing the
+// response, and linger is disabled first so the client sees a TCP reset
+// rather than a clean close.
+func resetConnection(c *fiber.Ctx) error {
+	conn := c.Context().Conn()
+	c.Context().HijackSetNoResponse(true)
+	c.Context().Hijack(func(net.Conn) {
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			tcp.SetLinger(0)
+		}
+	})
+	return nil
+}
+
+func faultRuleError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errFaultRuleNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminListFaults lists the fault injection rules
+// @Summary List fault rules
+// @Description Lists the fault injection rules in evaluation order
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} FaultRuleListResponse "Rules"
+// @Router /admin/faults [get]
+func adminListFaults(c *fiber.Ctx) error {
+	return c.JSON(FaultRuleListResponse{RuleList: faults.list()})
+}
+
+// adminAddFault adds a fault injection rule
+// @Summary Add fault rule
+// @Description Adds a rule that injects latency, 5xx responses, timeouts, connection resets or Shopee business errors. A rule without a probability always fires; a probability of 0 never does.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body FaultRule true "Rule to add"
+// @Success 200 {object} FaultRule "Added rule"
+// @Failure 400 {object} map[string]interface{} "Invalid rule"
+// @Router /admin/faults [post]
+func adminAddFault(c *fiber.Ctx) error {
+	var rule FaultRule
+
+	if err := c.BodyParser(&rule); err != nil {
+		return faultRuleError(c, errors.New("Invalid request body"))
+	}
+	if err := rule.validate(); err != nil {
+		return faultRuleError(c, err)
+	}
+
+	return c.JSON(faults.add(rule))
+}
+
+// adminUpdateFault replaces a fault injection rule
+// @Summary Update fault rule
+// @Description Replaces an existing fault injection rule, keeping its position
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Rule ID" example(1)
+// @Param request body FaultRule true "New rule"
+// @Success 200 {object} FaultRule "Updated rule"
+// @Failure 400 {object} map[string]interface{} "Invalid rule"
+// @Failure 404 {object} map[string]interface{} "Unknown rule"
+// @Router /admin/faults/{id} [put]
+func adminUpdateFault(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return faultRuleError(c, errFaultRuleNotFound)
+	}
+
+	var rule FaultRule
+	if err := c.BodyParser(&rule); err != nil {
+		return faultRuleError(c, errors.New("Invalid request body"))
+	}
+	if err := rule.validate(); err != nil {
+		return faultRuleError(c, err)
+	}
+
+	rule, err = faults.replace(id, rule)
+	if err != nil {
+		return faultRuleError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// adminDeleteFault removes a fault injection rule
+// @Summary Delete fault rule
+// @Description Removes one fault injection rule
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Rule ID" example(1)
+// @Success 204 "Rule removed"
+// @Failure 404 {object} map[string]interface{} "Unknown rule"
+// @Router /admin/faults/{id} [delete]
+func adminDeleteFault(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return faultRuleError(c, errFaultRuleNotFound)
+	}
+
+	if err := faults.remove(id); err != nil {
+		return faultRuleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// adminClearFaults removes every fault injection rule
+// @Summary Clear fault rules
+// @Description Removes every fault injection rule
+// @Tags Admin
+// @Success 204 "Rules removed"
+// @Router /admin/faults [delete]
+func adminClearFaults(c *fiber.Ctx) error {
+	faults.clear()
+	return c.SendStatus(204)
+}
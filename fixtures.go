@@ -0,0 +1,332 @@
+package main
+
+import "time"
+
+// newMockOrder returns the canned order the store starts with and new
+// orders are built from. Its amounts are in baht and its times are relative
+// to the virtual clock: it was placed ten days ago and completed three days
+// ago.
+func newMockOrder(orderSN string) OrderDetail {
+	now := clock.Now()
+	created := now.AddDate(0, 0, -10)
+	return OrderDetail{
+		ActualShippingFeeConfirmed: true,
+		BuyerCancelReason:          "",
+		BuyerCpfID:                 nil,
+		BuyerUserID:                121144654,
+		BuyerUsername:              "konlawatkkk",
+		CancelBy:                   "",
+		CancelReason:               "",
+		COD:                        true,
+		CreateTime:                 created.Unix(),
+		Currency:                   "THB",
+		DaysToShip:                 2,
+		Dropshipper:                nil,
+		DropshipperPhone:           nil,
+		EstimatedShippingFee:       35,
+		FulfillmentFlag:            "fulfilled_by_local_seller",
+		GoodsToDeclare:             false,
+		InvoiceData:                nil,
+		ItemList: []OrderItem{
+			{
+				AddOnDeal:              false,
+				AddOnDealID:            0,
+				ImageInfo:              ImageInfo{ImageURL: "https://cf.shopee.co.th/file/th-11134207-7r98r-lo113lk51dm8c8_tn"},
+				IsB2COwnedItem:         false,
+				IsPrescriptionItem:     false,
+				ItemID:                 10416502727,
+				ItemName:               "อุปกรณ์บันทึกเวลางานระบบไร้สาย IOMO Smart Beacon BC-1000 ใช้งานง่ายผ่าน Application",
+				ItemSKU:                "BC-1000",
+				MainItem:               false,
+				ModelDiscountedPrice:   1090,
+				ModelID:                10416502727,
+				ModelName:              "",
+				ModelOriginalPrice:     1090,
+				ModelQuantityPurchased: 1,
+				ModelSKU:               "",
+				OrderItemID:            10416502727,
+				ProductLocationID:      []string{"THZ"},
+				PromotionGroupID:       0,
+				PromotionID:            0,
+				PromotionType:          "flash_sale",
+				Weight:                 0.5,
+				Wholesale:              false,
+			},
+		},
+		MessageToSeller: "",
+		Note:            "",
+		NoteUpdateTime:  0,
+		OrderSN:         orderSN,
+		OrderStatus:     "COMPLETED",
+		PackageList: []PackageDetail{
+			{
+				GroupShipmentID: nil,
+				ItemList: []PackageItemDetail{
+					{
+						ItemID:            23620853561,
+						ModelID:           221404189791,
+						ModelQuantity:     1,
+						OrderItemID:       23620853561,
+						ProductLocationID: "VN10XX2UZ",
+						PromotionGroupID:  0,
+					},
+				},
+				LogisticsStatus:            "LOGISTICS_DELIVERY_DONE",
+				PackageNumber:              "OFG166300791210964",
+				ParcelChargeableWeightGram: 10,
+				ShippingCarrier:            "5-Day Delivery (SPX)",
+				LogisticsChannelID:         18080,
+				AllowSelfDesignAWB:         true,
+				SortingGroup:               "North",
+			},
+		},
+		PayTime:        created.Add(time.Minute).Unix(),
+		PaymentMethod:  "Credit Card/Debit Card",
+		PickupDoneTime: created.AddDate(0, 0, 1).Unix(),
+		RecipientAddress: RecipientAddress{
+			City:        "เขตห้วยขวาง",
+			District:    "แขวงบางกะปิ",
+			FullAddress: "****** พระราม 9 แขวงบางกะปิ แขวงบางกะปิ เขตห้วยขวาง จังหวัดกรุงเทพมหานคร 10310",
+			Name:        "ก******ง",
+			Phone:       "******17",
+			Region:      "TH",
+			State:       "จังหวัดกรุงเทพมหานคร",
+			Town:        "",
+			Zipcode:     "10310",
+		},
+		Region:             "TH",
+		ReverseShippingFee: 0,
+		ShipByDate:         0,
+		ShippingCarrier:    "Thunder Express",
+		SplitUp:            false,
+		TotalAmount:        1125,
+		UpdateTime:         now.AddDate(0, 0, -3).Unix(),
+	}
+}
+
+// newMockItem returns the canned item used to seed the product store,
+// listed thirty days before the virtual clock's current time.
+func newMockItem(itemID int64) ItemDetail {
+	created := clock.Now().AddDate(0, 0, -30)
+	return ItemDetail{
+		ItemID:      itemID,
+		CategoryID:  14646,
+		ItemName:    "seller discount",
+		Description: "first product 001first product",
+		ItemSKU:     "-",
+		CreateTime:  created.Unix(),
+		UpdateTime:  created.Add(3 * time.Second).Unix(),
+		AttributeList: []Attribute{
+			{
+				AttributeID:           4811,
+				OriginalAttributeName: "Brand: L2 Default [14644]",
+				IsMandatory:           true,
+				AttributeValueList: []AttributeValue{
+					{
+						ValueID:           0,
+						OriginalValueName: "Default",
+						ValueUnit:         "g",
+					},
+				},
+			},
+		},
+		PriceInfo: []PriceInfo{
+			{
+				Currency:                     "SGD",
+				OriginalPrice:                122.02,
+				CurrentPrice:                 122.02,
+				InflatedPriceOfOriginalPrice: 222.02,
+				InflatedPriceOfCurrentPrice:  111.02,
+				SipItemPrice:                 100.02,
+				SipItemPriceSource:           "auto",
+			},
+		},
+		Image: ItemImage{
+			ImageURLList: []string{media.imageURL(placeholderImageID)},
+			ImageIDList:  []string{placeholderImageID},
+		},
+		Weight: "10.02",
+		Dimension: Dimension{
+			PackageLength: 11,
+			PackageWidth:  12,
+			PackageHeight: 13,
+		},
+		LogisticInfo: []LogisticInfo{
+			{
+				LogisticID:           80012,
+				LogisticName:         "-",
+				Enabled:              true,
+				ShippingFee:          5.02,
+				SizeID:               0,
+				IsFree:               true,
+				EstimatedShippingFee: 4.02,
+			},
+		},
+		PreOrder: PreOrder{
+			IsPreOrder: true,
+			DaysToShip: 3,
+		},
+		Wholesales: []Wholesale{
+			{
+				MinCount:                 1,
+				MaxCount:                 2,
+				UnitPrice:                4.02,
+				InflatedPriceOfUnitPrice: 5.02,
+			},
+		},
+		Condition:   "NEW/USED",
+		SizeChart:   "-",
+		ItemStatus:  "NORMAL",
+		Deboost:     "false",
+		HasModel:    true,
+		PromotionID: 0,
+		VideoInfo:   []VideoInfo{},
+		Brand: Brand{
+			BrandID:           123,
+			OriginalBrandName: "nike",
+		},
+		ItemDangerous: 0,
+		ComplaintPolicy: ComplaintPolicy{
+			WarrantyTime:                "ONE_YEAR",
+			ExcludeEntrepreneurWarranty: true,
+			ComplaintAddressID:          0,
+			AdditionalInformation:       "-",
+		},
+		TaxInfo: TaxInfo{
+			NCM:           "-",
+			DiffStateCFOP: "-",
+			CSOSN:         "-",
+			Origin:        "-",
+			CEST:          "-",
+			MeasureUnit:   "-",
+			InvoiceOption: "-",
+			VATRate:       "-",
+			HSCode:        "-",
+			TaxCode:       "-",
+		},
+		DescriptionInfo: DescriptionInfo{
+			ExtendedDescription: ExtendedDescription{
+				FieldList: []DescriptionField{
+					{
+						FieldType: "-",
+						Text:      "-",
+						ImageInfo: DescriptionFieldImage{
+							ImageID:  "-",
+							ImageURL: "-",
+						},
+					},
+				},
+			},
+		},
+		DescriptionType: "-",
+		StockInfoV2: StockInfoV2{
+			SummaryInfo: SummaryInfo{
+				TotalReservedStock:  100,
+				TotalAvailableStock: 100,
+			},
+			SellerStock: []StockLocation{
+				{
+					LocationID: "-",
+					Stock:      10,
+				},
+			},
+			ShopeeStock: []StockLocation{
+				{
+					LocationID: "-",
+					Stock:      0,
+				},
+			},
+		},
+	}
+}
+
+// defaultCatalogue returns the category tree served until another one is
+// loaded. It includes the category and attribute used by newMockItem.
+func defaultCatalogue() Catalogue {
+	return Catalogue{
+		CategoryList: []CatalogueCategory{
+			{
+				CategoryID:           14643,
+				OriginalCategoryName: "Default L1",
+				DisplayCategoryName:  "Default L1",
+			},
+			{
+				CategoryID:           14644,
+				ParentCategoryID:     14643,
+				OriginalCategoryName: "Default L2",
+				DisplayCategoryName:  "Default L2",
+			},
+			{
+				CategoryID:           14646,
+				ParentCategoryID:     14644,
+				OriginalCategoryName: "Default L3",
+				DisplayCategoryName:  "Default L3",
+				AttributeList: []CatalogueAttribute{
+					{
+						AttributeID:           4811,
+						OriginalAttributeName: "Brand: L2 Default [14644]",
+						DisplayAttributeName:  "Brand: L2 Default [14644]",
+						IsMandatory:           true,
+						InputValidationType:   "STRING_TYPE",
+						FormatType:            "QUANTITATIVE",
+						InputType:             "COMBO_BOX",
+						AttributeUnit:         []string{"g", "kg"},
+						AttributeValueList: []CatalogueAttributeValue{
+							{ValueID: 61, OriginalValueName: "Default", DisplayValueName: "Default", ValueUnit: "g"},
+						},
+					},
+					{
+						AttributeID:           100037,
+						OriginalAttributeName: "Shelf Life",
+						DisplayAttributeName:  "Shelf Life",
+						IsMandatory:           false,
+						InputValidationType:   "STRING_TYPE",
+						FormatType:            "NORMAL",
+						InputType:             "DROP_DOWN",
+						AttributeValueList: []CatalogueAttributeValue{
+							{ValueID: 1010, OriginalValueName: "6 Months", DisplayValueName: "6 Months"},
+							{ValueID: 1011, OriginalValueName: "12 Months", DisplayValueName: "12 Months"},
+							{ValueID: 1012, OriginalValueName: "24 Months", DisplayValueName: "24 Months"},
+						},
+					},
+				},
+				BrandList: []CatalogueBrand{
+					{BrandID: 123, OriginalBrandName: "nike", DisplayBrandName: "Nike"},
+					{BrandID: 1146, OriginalBrandName: "adidas", DisplayBrandName: "Adidas"},
+				},
+			},
+			{
+				CategoryID:           100643,
+				OriginalCategoryName: "Mobile & Gadgets",
+				DisplayCategoryName:  "Mobile & Gadgets",
+			},
+			{
+				CategoryID:           101220,
+				ParentCategoryID:     100643,
+				OriginalCategoryName: "Wearable Devices",
+				DisplayCategoryName:  "Wearable Devices",
+				BrandIsMandatory:     true,
+				AttributeList: []CatalogueAttribute{
+					{
+						AttributeID:           100095,
+						OriginalAttributeName: "Warranty Type",
+						DisplayAttributeName:  "Warranty Type",
+						IsMandatory:           true,
+						InputValidationType:   "STRING_TYPE",
+						FormatType:            "NORMAL",
+						InputType:             "DROP_DOWN",
+						AttributeValueList: []CatalogueAttributeValue{
+							{ValueID: 2001, OriginalValueName: "No Warranty", DisplayValueName: "No Warranty"},
+							{ValueID: 2002, OriginalValueName: "Manufacturer Warranty", DisplayValueName: "Manufacturer Warranty"},
+							{ValueID: 2003, OriginalValueName: "Supplier Warranty", DisplayValueName: "Supplier Warranty"},
+						},
+					},
+				},
+				BrandList: []CatalogueBrand{
+					{BrandID: 3366, OriginalBrandName: "IOMO", DisplayBrandName: "IOMO"},
+					{BrandID: 1001, OriginalBrandName: "Xiaomi", DisplayBrandName: "Xiaomi"},
+				},
+			},
+		},
+	}
+}
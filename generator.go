@@ -0,0 +1,625 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Limits on one generator run.
+const (
+	maxGeneratedOrders = 1000000
+	maxGeneratedItems  = 100000
+)
+
+// GenerateDataRequest configures a generator run. The same seed, shop
+// region, counts, ratios and now always produce the same data.
+type GenerateDataRequest struct {
+	Seed         int64    `json:"seed" example:"42"`
+	ShopID       int64    `json:"shop_id" example:"789012"`
+	Orders       int      `json:"orders" example:"1000"`
+	Items        int      `json:"items" example:"50"`
+	CODRatio     *float64 `json:"cod_ratio" example:"0.4"`
+	VoucherRatio *float64 `json:"voucher_ratio" example:"0.2"`
+	Now          int64    `json:"now" example:"1758274838"`
+}
+
+type GenerateDataResponse struct {
+	Seed          int64          `json:"seed" example:"42"`
+	ShopID        int64          `json:"shop_id" example:"789012"`
+	ItemCount     int            `json:"item_count" example:"50"`
+	OrderCount    int            `json:"order_count" example:"1000"`
+	SkippedOrders int            `json:"skipped_orders" example:"0"`
+	FirstItemID   int64          `json:"first_item_id" example:"34003"`
+	StatusCount   map[string]int `json:"status_count"`
+}
+
+// GeneratedData is the output of a generator run, as written by the
+// generate command.
+type GeneratedData struct {
+	Seed      int64         `json:"seed"`
+	ItemList  []ItemDetail  `json:"item_list"`
+	OrderList []OrderDetail `json:"order_list"`
+}
+
+func (r GenerateDataRequest) validate() error {
+	switch {
+	case r.Orders < 0 || r.Orders > maxGeneratedOrders:
+		return fmt.Errorf("orders must be between 0 and %d", maxGeneratedOrders)
+	case r.Items < 0 || r.Items > maxGeneratedItems:
+		return fmt.Errorf("items must be between 0 and %d", maxGeneratedItems)
+	case r.CODRatio != nil && (*r.CODRatio < 0 || *r.CODRatio > 1):
+		return errors.New("cod_ratio must be between 0 and 1")
+	case r.VoucherRatio != nil && (*r.VoucherRatio < 0 || *r.VoucherRatio > 1):
+		return errors.New("voucher_ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+type weighted struct {
+	value  string
+	weight int
+}
+
+// generatedStatuses is roughly the status mix of a shop's last month of
+// orders: most are done, a few are still moving and some were cancelled.
+var generatedStatuses = []weighted{
+	{"COMPLETED", 45},
+	{"SHIPPED", 12},
+	{"READY_TO_SHIP", 12},
+	{"PROCESSED", 6},
+	{"UNPAID", 5},
+	{"TO_CONFIRM_RECEIVE", 5},
+	{"CANCELLED", 10},
+	{"IN_CANCEL", 2},
+	{"TO_RETURN", 3},
+}
+
+var generatedCancelBy = []weighted{
+	{"buyer", 6},
+	{"seller", 2},
+	{"system", 2},
+}
+
+var generatedCancelReasons = map[string][]string{
+	"buyer":  {"Need to change delivery address", "Need to modify order", "Found cheaper elsewhere", "Change of mind"},
+	"seller": {"Out of Stock"},
+	"system": {"Unpaid"},
+}
+
+var generatedLogisticsStatus = map[string]string{
+	"UNPAID":             "LOGISTICS_NOT_START",
+	"READY_TO_SHIP":      "LOGISTICS_READY",
+	"PROCESSED":          "LOGISTICS_REQUEST_CREATED",
+	"SHIPPED":            "LOGISTICS_PICKUP_DONE",
+	"TO_CONFIRM_RECEIVE": "LOGISTICS_DELIVERY_DONE",
+	"COMPLETED":          "LOGISTICS_DELIVERY_DONE",
+	"CANCELLED":          "LOGISTICS_INVALID",
+	"IN_CANCEL":          "LOGISTICS_READY",
+	"TO_RETURN":          "LOGISTICS_DELIVERY_DONE",
+}
+
+// regionPeople holds the names, phone prefixes and places buyers are drawn
+// from. Regions without an entry use their profile's sample buyer.
+type regionPeople struct {
+	names        func(rng *rand.Rand) string
+	phonePrefix  []string
+	places       []AddressBreakdown
+	street       func(rng *rand.Rand) string
+	productNames []string
+}
+
+var generatedPeople = map[string]regionPeople{
+	"TH": {
+		names: func(rng *rand.Rand) string {
+			return pick(rng, []string{"สมชาย", "สมหญิง", "ณัฐพล", "ปิยะนุช", "ธนากร", "วรรณา", "กิตติพงษ์", "อรุณี", "ศุภชัย", "พิมพ์ชนก"}) +
+				" " + pick(rng, []string{"ใจดี", "สุขสวัสดิ์", "วงศ์ไทย", "ศรีสุข", "แก้วมณี", "บุญมา", "ทองดี", "พรหมมา"})
+		},
+		phonePrefix: []string{"06", "08", "09"},
+		places: []AddressBreakdown{
+			{State: "จังหวัดกรุงเทพมหานคร", City: "เขตจตุจักร", District: "แขวงลาดยาว", Postcode: "10900"},
+			{State: "จังหวัดกรุงเทพมหานคร", City: "เขตห้วยขวาง", District: "แขวงบางกะปิ", Postcode: "10310"},
+			{State: "จังหวัดเชียงใหม่", City: "อำเภอเมืองเชียงใหม่", District: "ตำบลสุเทพ", Postcode: "50200"},
+			{State: "จังหวัดขอนแก่น", City: "อำเภอเมืองขอนแก่น", District: "ตำบลในเมือง", Postcode: "40000"},
+			{State: "จังหวัดภูเก็ต", City: "อำเภอเมืองภูเก็ต", District: "ตำบลตลาดใหญ่", Postcode: "83000"},
+			{State: "จังหวัดชลบุรี", City: "อำเภอบางละมุง", District: "ตำบลหนองปรือ", Postcode: "20150"},
+			{State: "จังหวัดนนทบุรี", City: "อำเภอปากเกร็ด", District: "ตำบลบางตลาด", Postcode: "11120"},
+		},
+		street: func(rng *rand.Rand) string {
+			return fmt.Sprintf("%d/%d หมู่ %d", 1+rng.Intn(300), 1+rng.Intn(99), 1+rng.Intn(12))
+		},
+		productNames: []string{
+			"เสื้อยืดคอกลม ผ้าฝ้าย 100%", "กระเป๋าสะพายข้าง หนังแท้", "หูฟังบลูทูธไร้สาย", "ครีมกันแดด SPF50 PA+++",
+			"แก้วเก็บความเย็น 30 ออนซ์", "รองเท้าผ้าใบ น้ำหนักเบา", "สายชาร์จ USB-C ชาร์จเร็ว", "หมอนยางพาราแท้",
+			"ชุดเครื่องนอน 6 ฟุต", "นาฬิกาข้อมือดิจิทัล",
+		},
+	},
+	"VN": {
+		names: func(rng *rand.Rand) string {
+			return pick(rng, []string{"Nguyễn", "Trần", "Lê", "Phạm", "Hoàng", "Huỳnh", "Phan", "Vũ", "Đặng", "Bùi"}) +
+				" " + pick(rng, []string{"Văn", "Thị", "Minh", "Ngọc", "Đức", "Thu"}) +
+				" " + pick(rng, []string{"An", "Bình", "Châu", "Dũng", "Hà", "Hùng", "Lan", "Linh", "Mai", "Nam", "Phương", "Quân", "Trang", "Tuấn"})
+		},
+		phonePrefix: []string{"03", "08", "09"},
+		places: []AddressBreakdown{
+			{State: "TP. Hồ Chí Minh", City: "Quận 1", District: "Phường Bến Nghé", Postcode: "700000"},
+			{State: "TP. Hồ Chí Minh", City: "Quận 3", District: "Phường 7", Postcode: "700000"},
+			{State: "Hà Nội", City: "Quận Cầu Giấy", District: "Phường Dịch Vọng", Postcode: "100000"},
+			{State: "Đà Nẵng", City: "Quận Hải Châu", District: "Phường Thạch Thang", Postcode: "550000"},
+			{State: "Cần Thơ", City: "Quận Ninh Kiều", District: "Phường An Hòa", Postcode: "900000"},
+			{State: "Hải Phòng", City: "Quận Lê Chân", District: "Phường An Biên", Postcode: "180000"},
+		},
+		street: func(rng *rand.Rand) string {
+			return fmt.Sprintf("%d %s", 1+rng.Intn(400), pick(rng, []string{"Lê Lợi", "Trần Hưng Đạo", "Nguyễn Huệ", "Hai Bà Trưng", "Lý Thường Kiệt"}))
+		},
+		productNames: []string{
+			"Áo thun cổ tròn cotton", "Túi đeo chéo da thật", "Tai nghe bluetooth không dây", "Kem chống nắng SPF50",
+			"Ly giữ nhiệt 900ml", "Giày thể thao siêu nhẹ", "Cáp sạc nhanh USB-C", "Gối cao su non",
+			"Bộ chăn ga gối 1m8", "Đồng hồ điện tử",
+		},
+	},
+}
+
+var genericProductNames = []string{
+	"Cotton Crew Neck T-Shirt", "Genuine Leather Crossbody Bag", "Wireless Bluetooth Earbuds", "Sunscreen SPF50",
+	"Insulated Tumbler 900ml", "Lightweight Running Shoes", "USB-C Fast Charging Cable", "Memory Foam Pillow",
+	"Bedding Set King Size", "Digital Wrist Watch",
+}
+
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.Intn(len(values))]
+}
+
+func pickWeighted(rng *rand.Rand, values []weighted) string {
+	total := 0
+	for _, v := range values {
+		total += v.weight
+	}
+	n := rng.Intn(total)
+	for _, v := range values {
+		if n < v.weight {
+			return v.value
+		}
+		n -= v.weight
+	}
+	return values[len(values)-1].value
+}
+
+// between returns a random duration in [min, max).
+func between(rng *rand.Rand, min, max time.Duration) time.Duration {
+	return min + time.Duration(rng.Int63n(int64(max-min)))
+}
+
+type dataGenerator struct {
+	rng          *rand.Rand
+	shop         Shop
+	profile      RegionProfile
+	people       regionPeople
+	now          time.Time
+	codRatio     float64
+	voucherRatio float64
+	orderSNs     map[string]bool
+}
+
+// generateData creates items with IDs from firstItemID and orders whose
+// baskets draw on those items, or on existing when no items are generated.
+// Popular items appear in far more baskets than the rest.
+func generateData(req GenerateDataRequest, shop Shop, firstItemID int64, existing []ItemDetail) (GeneratedData, error) {
+	if err := req.validate(); err != nil {
+		return GeneratedData{}, err
+	}
+
+	g := &dataGenerator{
+		rng:          rand.New(rand.NewSource(req.Seed)),
+		shop:         shop,
+		profile:      regionProfile(shop.Region),
+		now:          clock.Now(),
+		codRatio:     0.4,
+		voucherRatio: 0.2,
+		orderSNs:     make(map[string]bool),
+	}
+	if people, ok := generatedPeople[g.profile.Region]; ok {
+		g.people = people
+	}
+	if req.Now != 0 {
+		g.now = time.Unix(req.Now, 0)
+	}
+	if req.CODRatio != nil {
+		g.codRatio = *req.CODRatio
+	}
+	if req.VoucherRatio != nil {
+		g.voucherRatio = *req.VoucherRatio
+	}
+
+	data := GeneratedData{Seed: req.Seed, ItemList: []ItemDetail{}, OrderList: []OrderDetail{}}
+	for i := 0; i < req.Items; i++ {
+		data.ItemList = append(data.ItemList, g.item(firstItemID+int64(i)))
+	}
+
+	basket := data.ItemList
+	if len(basket) == 0 {
+		basket = existing
+	}
+	if req.Orders > 0 && len(basket) == 0 {
+		return GeneratedData{}, errors.New("the shop has no items to order; generate some items as well")
+	}
+	var popularity *rand.Zipf
+	if len(basket) > 1 {
+		popularity = rand.NewZipf(g.rng, 1.2, 1, uint64(len(basket)-1))
+	}
+	for i := 0; i < req.Orders; i++ {
+		data.OrderList = append(data.OrderList, g.order(basket, popularity))
+	}
+	return data, nil
+}
+
+func (g *dataGenerator) item(itemID int64) ItemDetail {
+	names := g.people.productNames
+	if names == nil {
+		names = genericProductNames
+	}
+
+	created := g.now.Add(-between(g.rng, time.Hour, 90*24*time.Hour))
+	item := newMockItem(itemID)
+	item.ItemName = pick(g.rng, names)
+	item.Description = item.ItemName
+	item.ItemSKU = fmt.Sprintf("GEN-%06d", itemID)
+	item.CreateTime = created.Unix()
+	item.UpdateTime = created.Add(between(g.rng, time.Second, g.now.Sub(created))).Unix()
+	item.PromotionID = 0
+	item.ItemStatus = "NORMAL"
+	if g.rng.Float64() < 0.05 {
+		item.ItemStatus = "UNLIST"
+	}
+
+	// Prices are log-uniform between 59 and 2,990 baht, and about a third
+	// of items are on sale.
+	current := g.profile.fromTHB(math.Round(math.Exp(math.Log(59) + g.rng.Float64()*(math.Log(2990)-math.Log(59)))))
+	original := current
+	if g.rng.Float64() < 0.3 {
+		original = g.profile.price(current * (1.1 + 0.4*g.rng.Float64()))
+	}
+	item.PriceInfo = []PriceInfo{{
+		Currency:                     g.shop.Currency,
+		OriginalPrice:                original,
+		CurrentPrice:                 current,
+		InflatedPriceOfOriginalPrice: original,
+		InflatedPriceOfCurrentPrice:  current,
+		SipItemPrice:                 current,
+		SipItemPriceSource:           "auto",
+	}}
+
+	stock := g.rng.Intn(500)
+	item.StockInfoV2 = StockInfoV2{
+		SummaryInfo: SummaryInfo{TotalAvailableStock: stock},
+		SellerStock: []StockLocation{{LocationID: g.profile.Region + "Z", Stock: stock}},
+		ShopeeStock: []StockLocation{},
+	}
+	return item
+}
+
+func (g *dataGenerator) order(basket []ItemDetail, popularity *rand.Zipf) OrderDetail {
+	created := g.now.Add(-between(g.rng, time.Minute, 30*24*time.Hour))
+	order := newRegionalMockOrder(g.orderSN(created), g.shop)
+	order.OrderStatus = pickWeighted(g.rng, generatedStatuses)
+	order.BuyerUserID = 100000000 + g.rng.Int63n(1900000000)
+	order.BuyerUsername = g.username()
+	order.DaysToShip = 2 + g.rng.Intn(2)
+	order.MessageToSeller = ""
+
+	name, phone, address := g.buyer()
+	order.RecipientAddress = g.profile.maskedAddress(address, name, phone)
+	order.PaymentMethod = g.paymentMethod()
+	order.COD = order.PaymentMethod == "Cash on Delivery"
+	order.ShippingCarrier = pick(g.rng, g.profile.Carriers)
+
+	// Most baskets hold one item; a few hold up to five.
+	size := 1
+	switch n := g.rng.Float64(); {
+	case n > 0.95:
+		size = 4 + g.rng.Intn(2)
+	case n > 0.85:
+		size = 3
+	case n > 0.6:
+		size = 2
+	}
+	if size > len(basket) {
+		size = len(basket)
+	}
+
+	order.ItemList = nil
+	var packageItems []PackageItemDetail
+	var subtotal float64
+	picked := make(map[int]bool)
+	for len(picked) < size {
+		i := 0
+		if popularity != nil {
+			i = int(popularity.Uint64())
+		}
+		if picked[i] {
+			i = g.rng.Intn(len(basket))
+		}
+		if picked[i] {
+			continue
+		}
+		picked[i] = true
+
+		item := basket[i]
+		quantity := 1
+		switch n := g.rng.Float64(); {
+		case n > 0.95:
+			quantity = 3 + g.rng.Intn(3)
+		case n > 0.8:
+			quantity = 2
+		}
+		var original, current float64
+		if len(item.PriceInfo) > 0 {
+			original = g.profile.price(item.PriceInfo[0].OriginalPrice)
+			current = g.profile.price(item.PriceInfo[0].CurrentPrice)
+		}
+		order.ItemList = append(order.ItemList, OrderItem{
+			ItemID:                 item.ItemID,
+			ItemName:               item.ItemName,
+			ItemSKU:                item.ItemSKU,
+			ModelDiscountedPrice:   current,
+			ModelOriginalPrice:     original,
+			ModelQuantityPurchased: quantity,
+			OrderItemID:            item.ItemID,
+			ProductLocationID:      []string{g.profile.Region + "Z"},
+		})
+		packageItems = append(packageItems, PackageItemDetail{
+			ItemID:            item.ItemID,
+			ModelQuantity:     quantity,
+			OrderItemID:       item.ItemID,
+			ProductLocationID: g.profile.Region + "Z",
+		})
+		subtotal += current * float64(quantity)
+	}
+
+	// Shipping is free on a third of orders; a voucher takes 5-15% off,
+	// funded by the seller on 60% of them and by Shopee on the rest.
+	order.EstimatedShippingFee = 0
+	if g.rng.Float64() >= 1.0/3 {
+		order.EstimatedShippingFee = g.profile.fromTHB(float64(25 + 10*g.rng.Intn(3)))
+	}
+	if g.rng.Float64() < g.voucherRatio {
+		voucher := g.profile.price(subtotal * (0.05 + 0.1*g.rng.Float64()))
+		if g.rng.Float64() < 0.6 {
+			order.VoucherFromSeller = voucher
+		} else {
+			order.VoucherFromShopee = voucher
+		}
+	}
+	order.TotalAmount = g.profile.price(subtotal + order.EstimatedShippingFee - order.VoucherFromSeller - order.VoucherFromShopee)
+
+	pkg := &order.PackageList[0]
+	pkg.ItemList = packageItems
+	pkg.PackageNumber = "OFG" + g.digits(15)
+	pkg.ShippingCarrier = order.ShippingCarrier
+	pkg.LogisticsStatus = generatedLogisticsStatus[order.OrderStatus]
+
+	g.timeline(&order, created)
+	return order
+}
+
+// timeline sets an order's times and cancellation details to match its
+// status. No time is later than the generator's now.
+func (g *dataGenerator) timeline(order *OrderDetail, created time.Time) {
+	clamp := func(t time.Time) time.Time {
+		if t.After(g.now) {
+			return g.now
+		}
+		return t
+	}
+
+	paid := clamp(created.Add(between(g.rng, time.Minute, 2*time.Hour)))
+	pickup := clamp(paid.Add(between(g.rng, 4*time.Hour, 48*time.Hour)))
+	delivered := clamp(pickup.Add(between(g.rng, 24*time.Hour, 96*time.Hour)))
+	updated := paid
+
+	order.CreateTime = created.Unix()
+	order.PayTime = paid.Unix()
+	order.PickupDoneTime = 0
+	order.ShipByDate = paid.AddDate(0, 0, order.DaysToShip).Unix()
+	order.CancelBy = ""
+	order.CancelReason = ""
+	order.BuyerCancelReason = ""
+
+	switch order.OrderStatus {
+	case "UNPAID":
+		order.PayTime = 0
+		order.ShipByDate = 0
+		updated = created
+	case "PROCESSED":
+		updated = clamp(paid.Add(between(g.rng, time.Minute, 24*time.Hour)))
+	case "SHIPPED":
+		order.PickupDoneTime = pickup.Unix()
+		updated = pickup
+	case "TO_CONFIRM_RECEIVE", "TO_RETURN":
+		order.PickupDoneTime = pickup.Unix()
+		updated = delivered
+	case "COMPLETED":
+		order.PickupDoneTime = pickup.Unix()
+		updated = clamp(delivered.Add(between(g.rng, 72*time.Hour, 168*time.Hour)))
+	case "IN_CANCEL":
+		order.BuyerCancelReason = pick(g.rng, generatedCancelReasons["buyer"])
+		updated = clamp(paid.Add(between(g.rng, time.Minute, 24*time.Hour)))
+	case "CANCELLED":
+		order.CancelBy = pickWeighted(g.rng, generatedCancelBy)
+		order.CancelReason = pick(g.rng, generatedCancelReasons[order.CancelBy])
+		if order.CancelBy == "buyer" {
+			order.BuyerCancelReason = order.CancelReason
+		}
+		if order.CancelBy == "system" {
+			order.PayTime = 0
+			order.ShipByDate = 0
+			updated = clamp(created.Add(24 * time.Hour))
+		} else {
+			updated = clamp(paid.Add(between(g.rng, time.Minute, 24*time.Hour)))
+		}
+	}
+	order.UpdateTime = updated.Unix()
+}
+
+func (g *dataGenerator) buyer() (name, phone string, address AddressBreakdown) {
+	if g.people.names == nil {
+		phone := g.profile.BuyerPhone
+		if len(phone) > 4 {
+			phone = phone[:len(phone)-4] + g.digits(4)
+		}
+		return g.profile.BuyerName, phone, g.profile.Address
+	}
+	address = g.people.places[g.rng.Intn(len(g.people.places))]
+	address.DetailedAddress = g.people.street(g.rng)
+	return g.people.names(g.rng), pick(g.rng, g.people.phonePrefix) + g.digits(8), address
+}
+
+// paymentMethod picks cash on delivery at the configured ratio in markets
+// that offer it, and one of the market's other methods otherwise.
+func (g *dataGenerator) paymentMethod() string {
+	var others []string
+	hasCOD := false
+	for _, method := range g.profile.PaymentMethods {
+		if method == "Cash on Delivery" {
+			hasCOD = true
+		} else {
+			others = append(others, method)
+		}
+	}
+	if hasCOD && g.rng.Float64() < g.codRatio {
+		return "Cash on Delivery"
+	}
+	return pick(g.rng, others)
+}
+
+func (g *dataGenerator) username() string {
+	b := make([]byte, 6+g.rng.Intn(5))
+	for i := range b {
+		b[i] = randomAlphabet[10+g.rng.Intn(26)]
+	}
+	return string(b) + g.digits(g.rng.Intn(4))
+}
+
+func (g *dataGenerator) digits(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0' + byte(g.rng.Intn(10))
+	}
+	return string(b)
+}
+
+// orderSN returns an order SN in Shopee's format that this run has not
+// used yet.
+func (g *dataGenerator) orderSN(created time.Time) string {
+	for {
+		b := make([]byte, 8)
+		for i := range b {
+			b[i] = orderSNAlphabet[g.rng.Intn(len(orderSNAlphabet))]
+		}
+		sn := created.Format("060102") + string(b)
+		if !g.orderSNs[sn] {
+			g.orderSNs[sn] = true
+			return sn
+		}
+	}
+}
+
+// adminGenerateData fills a shop with synthetic orders and items
+// @Summary Generate data
+// @Description Creates items and orders for a shop with realistic distributions of status, basket size, item popularity, cash on delivery, vouchers, local buyer names and masked addresses. The same seed and now give the same data. Generated orders do not reserve stock or push status changes. Orders draw on the generated items, or on the shop's existing items when items is 0.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body GenerateDataRequest true "Generator settings"
+// @Success 200 {object} GenerateDataResponse "Summary of the generated data"
+// @Failure 400 {object} map[string]interface{} "Invalid settings"
+// @Failure 404 {object} map[string]interface{} "Unknown shop"
+// @Router /admin/generate [post]
+func adminGenerateData(c *fiber.Ctx) error {
+	var req GenerateDataRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return tenantAdminError(c, errors.New("Invalid request body"))
+	}
+	if err := req.validate(); err != nil {
+		return tenantAdminError(c, err)
+	}
+	shop, ok := tenants.shop(resolveShopID(req.ShopID))
+	if !ok {
+		return tenantAdminError(c, errShopNotFound)
+	}
+
+	firstItemID := store.reserveItemIDs(req.Items)
+	data, err := generateData(req, shop, firstItemID, store.shopItems(shop.ShopID))
+	if err != nil {
+		return tenantAdminError(c, err)
+	}
+	imported := store.importData(shop.ShopID, data.ItemList, data.OrderList)
+
+	statusCount := map[string]int{}
+	for _, order := range data.OrderList {
+		statusCount[order.OrderStatus]++
+	}
+	return c.JSON(GenerateDataResponse{
+		Seed:          req.Seed,
+		ShopID:        shop.ShopID,
+		ItemCount:     len(data.ItemList),
+		OrderCount:    imported,
+		SkippedOrders: len(data.OrderList) - imported,
+		FirstItemID:   firstItemID,
+		StatusCount:   statusCount,
+	})
+}
+
+// runGenerateCommand implements the generate subcommand, which writes
+// generated data as JSON instead of serving it.
+func runGenerateCommand(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	var req GenerateDataRequest
+	var codRatio, voucherRatio float64
+	var region, out string
+	flags.Int64Var(&req.Seed, "seed", 1, "random seed; the same seed gives the same data")
+	flags.IntVar(&req.Orders, "orders", 1000, "number of orders")
+	flags.IntVar(&req.Items, "items", 50, "number of items")
+	flags.Float64Var(&codRatio, "cod-ratio", 0.4, "share of orders paid cash on delivery, where offered")
+	flags.Float64Var(&voucherRatio, "voucher-ratio", 0.2, "share of orders with a voucher")
+	flags.Int64Var(&req.Now, "now", 0, "unix time the data is generated relative to (default the current time)")
+	flags.Int64Var(&req.ShopID, "shop-id", defaultShopID, "shop ID")
+	flags.StringVar(&region, "region", "TH", "region profile the data follows")
+	flags.StringVar(&out, "o", "", "output file (default standard output)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	req.CODRatio = &codRatio
+	req.VoucherRatio = &voucherRatio
+
+	profile, ok := regionProfiles[region]
+	if !ok {
+		return fmt.Errorf("region %q is not supported", region)
+	}
+	shop := Shop{ShopID: req.ShopID, Region: profile.Region, Currency: profile.Currency}
+
+	data, err := generateData(req, shop, store.reserveItemIDs(req.Items), nil)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return json.NewEncoder(w).Encode(data)
+}
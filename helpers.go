@@ -0,0 +1,129 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	orderSNAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	randomAlphabet  = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// newRequestID returns a random 32 character hex string in the format Shopee
+// uses for request_id.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// newOrderSN returns an order SN in Shopee's format: the creation date as
+// YYMMDD followed by eight random characters, e.g. 2404098R48U37H.
+func newOrderSN(created time.Time) string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	for i := range b {
+		b[i] = orderSNAlphabet[int(b[i])%len(orderSNAlphabet)]
+	}
+	return created.Format("060102") + string(b)
+}
+
+// randomString returns n random lowercase letters and digits.
+func randomString(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	for i := range b {
+		b[i] = randomAlphabet[int(b[i])%len(randomAlphabet)]
+	}
+	return string(b)
+}
+
+// getEnv returns the value of an environment variable, or fallback when it
+// is unset or empty.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvInt is getEnv for integer settings; unparsable values fall back too.
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// randomDigits returns n random decimal digits.
+func randomDigits(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	for i := range b {
+		b[i] = '0' + b[i]%10
+	}
+	return string(b)
+}
+
+// parseIDList parses an ID list given either as a JSON style array
+// ("[34001,34002]") or as a plain comma-separated list ("34001,34002").
+func parseIDList(s string) ([]int64, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimSuffix(s, "]")
+
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// pageRange checks page_no and page_size parameters, defaulting them when
+// unset, and returns the range of a list of n entries they cover.
+func pageRange(pageNo, pageSize, defaultSize, maxSize, n int) (int, int, error) {
+	if pageNo == 0 {
+		pageNo = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultSize
+	}
+	if pageNo < 0 || pageSize < 0 || pageSize > maxSize {
+		return 0, 0, fmt.Errorf("page_no must be positive and page_size between 1 and %d", maxSize)
+	}
+	start := min((pageNo-1)*pageSize, n)
+	return start, min(start+pageSize, n), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(list []int64, n int64) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
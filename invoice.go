@@ -0,0 +1,269 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxInvoiceQueries is the most orders get_buyer_invoice_info accepts in
+// one call.
+const maxInvoiceQueries = 100
+
+var branchTaxIDPattern = regexp.MustCompile(`^[0-9]{5}$`)
+
+// BuyerInvoice is the invoice a buyer asked for when placing an order.
+// Company invoices carry the five digit branch number of the office the
+// invoice is addressed to; 00000 is the head office.
+type BuyerInvoice struct {
+	InvoiceType   string        `json:"invoice_type" example:"company"`
+	InvoiceDetail InvoiceDetail `json:"invoice_detail"`
+}
+
+// SetInvoiceResponse is the recorded invoice, with a warning when its tax
+// ID is invalid for the order's region. The invoice is stored either way.
+type SetInvoiceResponse struct {
+	BuyerInvoice
+	Warning string `json:"warning,omitempty" example:"invalid tax_id check digit for TH"`
+}
+
+func (i BuyerInvoice) validate() error {
+	switch i.InvoiceType {
+	case "personal":
+		if i.InvoiceDetail.BranchTaxID != "" {
+			return errors.New("branch_tax_id is only allowed on company invoices")
+		}
+	case "company":
+		if i.InvoiceDetail.TaxID == "" {
+			return errors.New("tax_id is required on company invoices")
+		}
+		if !branchTaxIDPattern.MatchString(i.InvoiceDetail.BranchTaxID) {
+			return errors.New("branch_tax_id must be five digits on company invoices")
+		}
+	default:
+		return errors.New("invoice_type must be personal or company")
+	}
+	if i.InvoiceDetail.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// adminSetInvoice records a buyer's invoice request for an order
+// @Summary Set buyer invoice
+// @Description Records the invoice the buyer asked for on an order, as returned by get_buyer_invoice_info. Replaces any earlier request. A tax ID that is invalid for the order's region is still recorded, with a warning.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param order_sn path string true "Order SN" example("2404098R48U37H")
+// @Param request body BuyerInvoice true "Invoice the buyer requested"
+// @Success 200 {object} SetInvoiceResponse "Recorded invoice"
+// @Failure 400 {object} map[string]interface{} "Invalid invoice"
+// @Failure 404 {object} map[string]interface{} "Unknown order"
+// @Router /admin/orders/{order_sn}/invoice [put]
+func adminSetInvoice(c *fiber.Ctx) error {
+	var invoice BuyerInvoice
+
+	if err := c.BodyParser(&invoice); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+	if err := invoice.validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": err.Error(),
+		})
+	}
+
+	order, err := store.setInvoice(strings.Clone(c.Params("order_sn")), &invoice)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(fiber.Map{
+			"error":   code,
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(SetInvoiceResponse{
+		BuyerInvoice: invoice,
+		Warning:      taxIDWarning(order.Region, &invoice),
+	})
+}
+
+// adminDeleteInvoice withdraws a buyer's invoice request
+// @Summary Delete buyer invoice
+// @Description Withdraws the invoice request on an order, so get_buyer_invoice_info reports it as not requested
+// @Tags Admin
+// @Param order_sn path string true "Order SN" example("2404098R48U37H")
+// @Success 204 "Invoice request withdrawn"
+// @Failure 404 {object} map[string]interface{} "Unknown order"
+// @Router /admin/orders/{order_sn}/invoice [delete]
+func adminDeleteInvoice(c *fiber.Ctx) error {
+	if _, err := store.setInvoice(strings.Clone(c.Params("order_sn")), nil); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(fiber.Map{
+			"error":   code,
+			"message": err.Error(),
+		})
+	}
+	return c.SendStatus(204)
+}
+
+// Invoice document file types accepted by upload_invoice_doc.
+const (
+	invoiceFilePDF = 1
+	invoiceFileJPG = 2
+)
+
+const maxInvoiceDocSize = 5 << 20
+
+var invoiceFileTypes = map[int]struct {
+	contentType string
+	ext         string
+}{
+	invoiceFilePDF: {"application/pdf", ".pdf"},
+	invoiceFileJPG: {"image/jpeg", ".jpg"},
+}
+
+// invoiceDoc is the invoice document a seller uploaded for an order.
+type invoiceDoc struct {
+	fileType   int
+	path       string
+	uploadTime int64
+}
+
+type UploadInvoiceDocResponse struct {
+	RequestID string `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string `json:"error" example:""`
+	Message   string `json:"message" example:""`
+}
+
+// uploadInvoiceDoc uploads the invoice issued for an order
+// @Summary Upload invoice document
+// @Description Stores the invoice the seller issued for an order whose buyer requested one. Later get_buyer_invoice_info calls report it as uploaded, and download_invoice_doc returns it.
+// @Tags Order
+// @Accept multipart/form-data
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param order_sn formData string true "Order SN" example("2404098R48U37H")
+// @Param file_type formData int true "1 for PDF, 2 for JPG" example(1)
+// @Param file formData file true "Invoice file, at most 5 MB"
+// @Success 200 {object} UploadInvoiceDocResponse "Success response"
+// @Failure 400 {object} UploadInvoiceDocResponse "Bad request or no invoice requested"
+// @Failure 404 {object} UploadInvoiceDocResponse "Unknown order"
+// @Router /api/v2/order/upload_invoice_doc [post]
+func uploadInvoiceDoc(c *fiber.Ctx) error {
+	orderSN := strings.Clone(c.FormValue("order_sn"))
+	if orderSN == "" {
+		return c.Status(400).JSON(UploadInvoiceDocResponse{
+			Error:   "error_param",
+			Message: "order_sn is required",
+		})
+	}
+
+	fileType, _ := strconv.Atoi(c.FormValue("file_type"))
+	format, ok := invoiceFileTypes[fileType]
+	if !ok {
+		return c.Status(400).JSON(UploadInvoiceDocResponse{
+			Error:   "error_param",
+			Message: "file_type must be 1 (PDF) or 2 (JPG)",
+		})
+	}
+
+	data, err := readFormFile(c, "file", maxInvoiceDocSize)
+	if err != nil {
+		return c.Status(400).JSON(UploadInvoiceDocResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+	if http.DetectContentType(data) != format.contentType {
+		return c.Status(400).JSON(UploadInvoiceDocResponse{
+			Error:   "error_param",
+			Message: fmt.Sprintf("file is not a %s file", strings.ToUpper(strings.TrimPrefix(format.ext, "."))),
+		})
+	}
+
+	// Check the order before writing so a bad request leaves no file behind.
+	shopID := currentShop(c).ShopID
+	if _, err := store.getInvoice(shopID, orderSN); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(UploadInvoiceDocResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	path := filepath.Join(media.dir, "invoices", randomString(16)+format.ext)
+	if err := writeFile(path, data); err != nil {
+		return c.Status(500).JSON(UploadInvoiceDocResponse{
+			Error:   "error_server",
+			Message: err.Error(),
+		})
+	}
+
+	doc := invoiceDoc{fileType: fileType, path: path, uploadTime: clock.Now().Unix()}
+	if err := store.setInvoiceDoc(shopID, orderSN, doc); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(UploadInvoiceDocResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(UploadInvoiceDocResponse{
+		RequestID: newRequestID(),
+	})
+}
+
+// downloadInvoiceDoc downloads the invoice uploaded for an order
+// @Summary Download invoice document
+// @Description Returns the invoice document last uploaded for an order
+// @Tags Order
+// @Produce application/pdf,image/jpeg
+// @Param order_sn query string true "Order SN" example("2404098R48U37H")
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Success 200 {file} file "Invoice document"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Unknown order or no document uploaded"
+// @Router /api/v2/order/download_invoice_doc [get]
+func downloadInvoiceDoc(c *fiber.Ctx) error {
+	orderSN := c.Query("order_sn")
+	if orderSN == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"request_id": newRequestID(),
+			"error":      "error_param",
+			"message":    "order_sn is required",
+		})
+	}
+
+	doc, err := store.getInvoiceDoc(currentShop(c).ShopID, orderSN)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(fiber.Map{
+			"request_id": newRequestID(),
+			"error":      code,
+			"message":    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, invoiceFileTypes[doc.fileType].contentType)
+	return c.SendFile(doc.path)
+}
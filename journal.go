@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxJournalEntries bounds the request journal; the oldest entries are
+// dropped first.
+const maxJournalEntries = 10000
+
+// maxJournalText is the largest non-JSON body kept in the journal. Larger
+// bodies, such as media uploads, are recorded by size only.
+const maxJournalText = 4096
+
+type RequestAuth struct {
+	PartnerID   string `json:"partner_id" example:"123456"`
+	ShopID      string `json:"shop_id" example:"789012"`
+	Timestamp   string `json:"timestamp" example:"1640995200"`
+	AccessToken string `json:"access_token" example:"your_access_token"`
+	Sign        string `json:"sign" example:"ABCD1234567890EFGH"`
+}
+
+// JournalEntry is one request received by the mock and the status it was
+// answered with.
+type JournalEntry struct {
+	ID           int64             `json:"id" example:"1"`
+	ReceivedAt   int64             `json:"received_at" example:"1758274838"`
+	Method       string            `json:"method" example:"POST"`
+	Path         string            `json:"path" example:"/api/v2/logistics/ship_order"`
+	Route        string            `json:"route" example:"/api/v2/logistics/ship_order"`
+	Query        map[string]string `json:"query"`
+	Auth         RequestAuth       `json:"auth"`
+	ContentType  string            `json:"content_type" example:"application/json"`
+	Body         json.RawMessage   `json:"body,omitempty" swaggertype:"object"`
+	BodyText     string            `json:"body_text,omitempty" example:""`
+	BodySize     int               `json:"body_size" example:"64"`
+	Status       int               `json:"status" example:"200"`
+	LatencyMilli int64             `json:"latency_ms" example:"1"`
+}
+
+// JournalFilter selects journal entries. Path may end in "*" to match a
+// prefix. Query and Body hold "name:value" conditions; body names are
+// dotted JSON paths, and a condition on an array holds if any element
+// matches it.
+type JournalFilter struct {
+	Method    string   `query:"method" example:"POST"`
+	Path      string   `query:"path" example:"/api/v2/logistics/ship_order"`
+	Route     string   `query:"route" example:"/api/v2/logistics/ship_order"`
+	Status    int      `query:"status" example:"200"`
+	PartnerID string   `query:"partner_id" example:"123456"`
+	ShopID    string   `query:"shop_id" example:"789012"`
+	From      int64    `query:"from" example:"1758274800"`
+	To        int64    `query:"to" example:"1758278400"`
+	Query     []string `query:"query" example:"order_sn_list:2404098R48U37H"`
+	Body      []string `query:"body" example:"pickup.address_id:123"`
+}
+
+type JournalListResponse struct {
+	Count       int            `json:"count" example:"1"`
+	RequestList []JournalEntry `json:"request_list"`
+}
+
+type requestJournal struct {
+	mu      sync.Mutex
+	entries []*JournalEntry
+	nextID  int64
+}
+
+var journal = &requestJournal{nextID: 1}
+
+func (j *requestJournal) record(entry JournalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.ID = j.nextID
+	j.nextID++
+	j.entries = append(j.entries, &entry)
+	if len(j.entries) > maxJournalEntries {
+		j.entries = j.entries[len(j.entries)-maxJournalEntries:]
+	}
+}
+
+func (j *requestJournal) list(filter JournalFilter) []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	list := []JournalEntry{}
+	for _, entry := range j.entries {
+		if filter.matches(entry) {
+			list = append(list, *entry)
+		}
+	}
+	return list
+}
+
+func (j *requestJournal) clear() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = nil
+}
+
+func (f JournalFilter) validate() error {
+	for _, cond := range append(append([]string{}, f.Query...), f.Body...) {
+		if !strings.Contains(cond, ":") {
+			return fmt.Errorf("condition %q must be name:value", cond)
+		}
+	}
+	return nil
+}
+
+func (f JournalFilter) matches(entry *JournalEntry) bool {
+	switch {
+	case f.Method != "" && !strings.EqualFold(entry.Method, f.Method):
+		return false
+	case f.Path != "" && !matchPath(f.Path, entry.Path):
+		return false
+	case f.Route != "" && entry.Route != f.Route:
+		return false
+	case f.Status != 0 && entry.Status != f.Status:
+		return false
+	case f.PartnerID != "" && entry.Auth.PartnerID != f.PartnerID:
+		return false
+	case f.ShopID != "" && entry.Auth.ShopID != f.ShopID:
+		return false
+	case f.From != 0 && entry.ReceivedAt < f.From:
+		return false
+	case f.To != 0 && entry.ReceivedAt > f.To:
+		return false
+	}
+
+	for _, cond := range f.Query {
+		name, value, _ := strings.Cut(cond, ":")
+		if entry.Query[name] != value {
+			return false
+		}
+	}
+
+	if len(f.Body) == 0 {
+		return true
+	}
+	var body interface{}
+	decoder := json.NewDecoder(bytes.NewReader(entry.Body))
+	decoder.UseNumber()
+	if entry.Body == nil || decoder.Decode(&body) != nil {
+		return false
+	}
+	for _, cond := range f.Body {
+		name, value, _ := strings.Cut(cond, ":")
+		if !jsonFieldMatches(body, strings.Split(name, "."), value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPath reports whether path matches pattern, which may end in "*" to
+// match a prefix.
+func matchPath(pattern, path string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return pattern == path
+}
+
+// jsonFieldMatches reports whether the value at a dotted path in a decoded
+// JSON document equals want. Numeric segments index arrays; any other
+// segment applied to an array is tried against every element.
+func jsonFieldMatches(node interface{}, path []string, want string) bool {
+	if len(path) == 0 {
+		switch v := node.(type) {
+		case []interface{}:
+			for _, elem := range v {
+				if jsonFieldMatches(elem, nil, want) {
+					return true
+				}
+			}
+			return false
+		case map[string]interface{}:
+			return false
+		case nil:
+			return want == "null"
+		default:
+			return fmt.Sprint(v) == want
+		}
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		return ok && jsonFieldMatches(child, path[1:], want)
+	case []interface{}:
+		if i, err := strconv.Atoi(path[0]); err == nil {
+			return i >= 0 && i < len(v) && jsonFieldMatches(v[i], path[1:], want)
+		}
+		for _, elem := range v {
+			if jsonFieldMatches(elem, path, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// journalRequests is a Fiber middleware that records every request and
+// the status it was answered with. Requests to the journal itself are not
+// recorded.
+func journalRequests(c *fiber.Ctx) error {
+	if strings.HasPrefix(c.Path(), "/admin/requests") {
+		return c.Next()
+	}
+
+	// Fiber's strings point into buffers it reuses once the request is
+	// done, so everything kept in the journal is copied.
+	started := time.Now()
+	entry := JournalEntry{
+		ReceivedAt:  clock.Now().Unix(),
+		Method:      strings.Clone(c.Method()),
+		Path:        strings.Clone(c.Path()),
+		Query:       map[string]string{},
+		ContentType: strings.Clone(c.Get(fiber.HeaderContentType)),
+		Auth: RequestAuth{
+			PartnerID:   strings.Clone(c.Query("partner_id")),
+			ShopID:      strings.Clone(c.Query("shop_id")),
+			Timestamp:   strings.Clone(c.Query("timestamp")),
+			AccessToken: strings.Clone(c.Query("access_token")),
+			Sign:        strings.Clone(c.Query("sign")),
+		},
+	}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		entry.Query[string(key)] = string(value)
+	})
+
+	body := c.Body()
+	entry.BodySize = len(body)
+	switch {
+	case len(body) > 0 && json.Valid(body):
+		entry.Body = append(json.RawMessage{}, body...)
+	case len(body) <= maxJournalText && utf8.Valid(body):
+		entry.BodyText = string(body)
+	}
+
+	err := c.Next()
+
+	entry.Route = strings.Clone(c.Route().Path)
+	entry.Status = c.Response().StatusCode()
+	if err != nil {
+		// The error handler has not written the response yet.
+		entry.Status = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			entry.Status = fiberErr.Code
+		}
+	}
+	entry.LatencyMilli = time.Since(started).Milliseconds()
+	journal.record(entry)
+	return err
+}
+
+// adminListRequests lists journalled requests
+// @Summary List requests
+// @Description Lists the requests the mock received, oldest first, with their query, auth parameters, body, matched route and response status. Conditions combine with AND, so count answers questions like "was ship_order called exactly once for this order".
+// @Tags Admin
+// @Produce json
+// @Param method query string false "HTTP method" example("POST")
+// @Param path query string false "Request path; may end in * to match a prefix" example("/api/v2/logistics/ship_order")
+// @Param route query string false "Matched route pattern" example("/admin/scenarios/:id")
+// @Param status query int false "Response status" example(200)
+// @Param partner_id query string false "partner_id the request was made with" example("123456")
+// @Param shop_id query string false "shop_id the request was made with" example("789012")
+// @Param from query int64 false "Earliest receive time, unix seconds" example(1758274800)
+// @Param to query int64 false "Latest receive time, unix seconds" example(1758278400)
+// @Param query query []string false "Query parameter condition name:value; may repeat" collectionFormat(multi) example(order_sn_list:2404098R48U37H)
+// @Param body query []string false "JSON body condition dotted.path:value; may repeat" collectionFormat(multi) example(pickup.address_id:123)
+// @Success 200 {object} JournalListResponse "Matching requests"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/requests [get]
+func adminListRequests(c *fiber.Ctx) error {
+	var filter JournalFilter
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid query parameters",
+		})
+	}
+	filter.Query = queryValues(c, "query")
+	filter.Body = queryValues(c, "body")
+
+	if err := filter.validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": err.Error(),
+		})
+	}
+
+	list := journal.list(filter)
+	return c.JSON(JournalListResponse{
+		Count:       len(list),
+		RequestList: list,
+	})
+}
+
+// queryValues returns every value of a repeated query parameter.
+func queryValues(c *fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range c.Context().QueryArgs().PeekMulti(key) {
+		values = append(values, string(value))
+	}
+	return values
+}
+
+// adminClearRequests empties the request journal
+// @Summary Clear requests
+// @Description Forgets every journalled request
+// @Tags Admin
+// @Success 204 "Journal cleared"
+// @Router /admin/requests [delete]
+func adminClearRequests(c *fiber.Ctx) error {
+	journal.clear()
+	return c.SendStatus(204)
+}
@@ -0,0 +1,91 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+type ShipOrderPickup struct {
+	AddressID      int64  `json:"address_id" example:"123"`
+	PickupTimeID   string `json:"pickup_time_id" example:"1758333600"`
+	TrackingNumber string `json:"tracking_number" example:""`
+}
+
+type ShipOrderDropoff struct {
+	BranchID       int64  `json:"branch_id" example:"0"`
+	SenderRealName string `json:"sender_real_name" example:""`
+	TrackingNumber string `json:"tracking_number" example:""`
+}
+
+type ShipOrderNonIntegrated struct {
+	TrackingNumber string `json:"tracking_number" example:"TH0123456789"`
+}
+
+type ShipOrderRequest struct {
+	OrderSN       string                  `json:"order_sn" example:"2404098R48U37H"`
+	PackageNumber string                  `json:"package_number" example:"OFG166300791210964"`
+	Pickup        *ShipOrderPickup        `json:"pickup,omitempty"`
+	Dropoff       *ShipOrderDropoff       `json:"dropoff,omitempty"`
+	NonIntegrated *ShipOrderNonIntegrated `json:"non_integrated,omitempty"`
+}
+
+type ShipOrderResponse struct {
+	RequestID string `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string `json:"error" example:""`
+	Message   string `json:"message" example:""`
+}
+
+// shipOrder arranges shipment for an order
+// @Summary Ship order
+// @Description Arranges pickup, dropoff or non-integrated shipment for an order and deducts its reserved stock
+// @Tags Logistics
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body ShipOrderRequest true "Order to ship"
+// @Success 200 {object} ShipOrderResponse "Success response"
+// @Failure 400 {object} ShipOrderResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/logistics/ship_order [post]
+func shipOrder(c *fiber.Ctx) error {
+	var req ShipOrderRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ShipOrderResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.OrderSN == "" {
+		return c.Status(400).JSON(ShipOrderResponse{
+			Error:   "error_param",
+			Message: "order_sn is required",
+		})
+	}
+
+	shop := currentShop(c)
+	trackingNo := regionProfile(shop.Region).trackingNumber()
+	switch {
+	case req.NonIntegrated != nil && req.NonIntegrated.TrackingNumber != "":
+		trackingNo = req.NonIntegrated.TrackingNumber
+	case req.Pickup != nil && req.Pickup.TrackingNumber != "":
+		trackingNo = req.Pickup.TrackingNumber
+	case req.Dropoff != nil && req.Dropoff.TrackingNumber != "":
+		trackingNo = req.Dropoff.TrackingNumber
+	}
+
+	if _, err := store.shipOrder(shop.ShopID, req.OrderSN, trackingNo, clock.Now()); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(ShipOrderResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(ShipOrderResponse{
+		RequestID: newRequestID(),
+	})
+}
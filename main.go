@@ -6,7 +6,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	_ "shopee-api/docs"
@@ -35,7 +37,7 @@ type GetBuyerInvoiceInfoRequest struct {
 }
 
 type GetOrderDetailRequest struct {
-	OrderSNList                 string `json:"order_sn_list" query:"order_sn_list" example:"201214JAJXU6G7,201214JASXYXY6"`
+	OrderSNList                 string `json:"order_sn_list" query:"order_sn_list" example:"2404098R48U37H,2404090000000X"`
 	PartnerID                   int64  `json:"partner_id" query:"partner_id" example:"123456"`
 	ShopID                      int64  `json:"shop_id" query:"shop_id" example:"789012"`
 	Timestamp                   int64  `json:"timestamp" query:"timestamp" example:"1640995200"`
@@ -74,6 +76,7 @@ type InvoiceDetail struct {
 	Address          string           `json:"address" example:"Ordona 7B Warszawa, Warszawa, 51120"`
 	PhoneNumber      string           `json:"phone_number" example:"0886761062"`
 	TaxID            string           `json:"tax_id" example:"0745561010054"`
+	BranchTaxID      string           `json:"branch_tax_id,omitempty" example:"00001"`
 	AddressBreakdown AddressBreakdown `json:"address_breakdown"`
 }
 
@@ -82,6 +85,8 @@ type InvoiceInfo struct {
 	InvoiceType   string        `json:"invoice_type" example:"personal"`
 	InvoiceDetail InvoiceDetail `json:"invoice_detail"`
 	IsRequested   bool          `json:"is_requested" example:"false"`
+	IsUploaded    bool          `json:"is_uploaded" example:"false"`
+	UploadTime    int64         `json:"upload_time" example:"0"`
 	Error         string        `json:"error" example:""`
 }
 
@@ -106,10 +111,10 @@ type OrderItem struct {
 	ItemName                 string      `json:"item_name" example:"Minecraft NFA"`
 	ItemSKU                  string      `json:"item_sku" example:""`
 	MainItem                 bool        `json:"main_item" example:"false"`
-	ModelDiscountedPrice     int64       `json:"model_discounted_price" example:"48000"`
+	ModelDiscountedPrice     float64     `json:"model_discounted_price" example:"48000"`
 	ModelID                  int64       `json:"model_id" example:"221404189791"`
 	ModelName                string      `json:"model_name" example:"60g（Papaya）"`
-	ModelOriginalPrice       int64       `json:"model_original_price" example:"300000"`
+	ModelOriginalPrice       float64     `json:"model_original_price" example:"300000"`
 	ModelQuantityPurchased   int         `json:"model_quantity_purchased" example:"1"`
 	ModelSKU                 string      `json:"model_sku" example:"QAZ-SADOER-05"`
 	OrderItemID              int64       `json:"order_item_id" example:"23620853561"`
@@ -162,38 +167,45 @@ type OrderDetail struct {
 	BuyerUsername              string            `json:"buyer_username" example:"xt4fdsf96j"`
 	CancelBy                   string            `json:"cancel_by" example:""`
 	CancelReason               string            `json:"cancel_reason" example:""`
-	COD                        bool              `json:"cod" example:"true"`
-	CreateTime                 int64             `json:"create_time" example:"1712601591"`
-	Currency                   string            `json:"currency" example:"VND"`
-	DaysToShip                 int               `json:"days_to_ship" example:"2"`
+	COD                        bool              `json:"cod" example:"true" validate:"required"`
+	CreateTime                 int64             `json:"create_time" example:"1712601591" validate:"required"`
+	Currency                   string            `json:"currency" example:"VND" validate:"required"`
+	DaysToShip                 int               `json:"days_to_ship" example:"2" validate:"required"`
 	Dropshipper                *string           `json:"dropshipper" example:"null"`
 	DropshipperPhone           *string           `json:"dropshipper_phone" example:"null"`
-	EstimatedShippingFee       int64             `json:"estimated_shipping_fee" example:"5000"`
+	EstimatedShippingFee       float64           `json:"estimated_shipping_fee" example:"5000"`
 	FulfillmentFlag            string            `json:"fulfillment_flag" example:"fulfilled_by_local_seller"`
 	GoodsToDeclare             bool              `json:"goods_to_declare" example:"false"`
 	InvoiceData                *string           `json:"invoice_data" example:"null"`
 	ItemList                   []OrderItem       `json:"item_list"`
-	MessageToSeller            string            `json:"message_to_seller" example:""`
+	MessageToSeller            string            `json:"message_to_seller" example:"" validate:"required"`
 	Note                       string            `json:"note" example:""`
 	NoteUpdateTime             int64             `json:"note_update_time" example:"0"`
-	OrderSN                    string            `json:"order_sn" example:"2404098R48U37H"`
-	OrderStatus                string            `json:"order_status" example:"COMPLETED"`
+	OrderSN                    string            `json:"order_sn" example:"2404098R48U37H" validate:"required"`
+	OrderStatus                string            `json:"order_status" example:"COMPLETED" validate:"required"`
 	PackageList                []PackageDetail   `json:"package_list"`
 	PayTime                    int64             `json:"pay_time" example:"1712817766"`
 	PaymentMethod              string            `json:"payment_method" example:"Cash on Delivery"`
 	PickupDoneTime             int64             `json:"pickup_done_time" example:"1712726577"`
 	RecipientAddress           RecipientAddress  `json:"recipient_address"`
-	Region                     string            `json:"region" example:"VN"`
-	ReverseShippingFee         int64             `json:"reverse_shipping_fee" example:"0"`
-	ShipByDate                 int64             `json:"ship_by_date" example:"1712671200"`
+	Region                     string            `json:"region" example:"VN" validate:"required"`
+	ReverseShippingFee         float64           `json:"reverse_shipping_fee" example:"0" validate:"required"`
+	ShipByDate                 int64             `json:"ship_by_date" example:"1712671200" validate:"required"`
 	ShippingCarrier            string            `json:"shipping_carrier" example:"Thunder Express"`
 	SplitUp                    bool              `json:"split_up" example:"false"`
-	TotalAmount                int64             `json:"total_amount" example:"32119"`
-	UpdateTime                 int64             `json:"update_time" example:"1713139948"`
+	TotalAmount                float64           `json:"total_amount" example:"32119"`
+	UpdateTime                 int64             `json:"update_time" example:"1713139948" validate:"required"`
+
+	// Vouchers the buyer used, which Shopee only reports in the escrow
+	// detail
+	VoucherCode                string            `json:"-"`
+	VoucherFromSeller          float64           `json:"-"`
+	VoucherFromShopee          float64           `json:"-"`
 }
 
 type OrderListResponse struct {
-	OrderList []OrderDetail `json:"order_list"`
+	OrderList           []OrderDetail `json:"order_list"`
+	NotFoundOrderSNList []string      `json:"not_found_order_sn_list" example:"2404090000000X"`
 }
 
 type GetOrderDetailResponse struct {
@@ -362,16 +374,16 @@ type ItemListResponse struct {
 }
 
 type GetItemBaseInfoResponse struct {
-	Error     string           `json:"error" example:"-"`
-	Message   string           `json:"message" example:"-"`
-	Warning   string           `json:"warning" example:"-"`
+	Error     string           `json:"error" example:""`
+	Message   string           `json:"message" example:""`
+	Warning   string           `json:"warning" example:""`
 	RequestID string           `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
 	Response  ItemListResponse `json:"response"`
 }
 
 // getBuyerInvoiceInfo retrieves buyer invoice information
 // @Summary Get buyer invoice information
-// @Description Retrieves buyer invoice information for a specific order
+// @Description Retrieves the invoices buyers requested for up to 100 orders. Orders with no invoice request, or that are not the shop's, get is_requested false and an error instead.
 // @Tags Order
 // @Accept json
 // @Produce json
@@ -406,7 +418,7 @@ func getBuyerInvoiceInfo(c *fiber.Ctx) error {
 		})
 	}
 
-	if len(req.Queries) == 0 || req.Queries[0].OrderSN == "" {
+	if len(req.Queries) == 0 {
 		return c.Status(400).JSON(GetBuyerInvoiceInfoResponse{
 			RequestID: "",
 			Error:     "missing_order_sn",
@@ -414,36 +426,45 @@ func getBuyerInvoiceInfo(c *fiber.Ctx) error {
 		})
 	}
 
+	if len(req.Queries) > maxInvoiceQueries {
+		return c.Status(400).JSON(GetBuyerInvoiceInfoResponse{
+			RequestID: newRequestID(),
+			Error:     "error_param",
+			Message:   fmt.Sprintf("queries must contain at most %d entries", maxInvoiceQueries),
+		})
+	}
+
+	// Look up each order's invoice in the shop; orders without one get an
+	// entry with the reason instead of failing the whole request. Tax IDs
+	// that fail the shop region's format are still returned, but flagged
+	shop := currentShop(c)
+	shopID := shop.ShopID
+	infos := []InvoiceInfo{}
+	for _, query := range req.Queries {
+		info := InvoiceInfo{OrderSN: query.OrderSN}
+		invoice, err := store.getInvoice(shopID, query.OrderSN)
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.InvoiceType = invoice.InvoiceType
+			info.InvoiceDetail = invoice.InvoiceDetail
+			info.IsRequested = true
+			if err := checkInvoiceTaxID(shop.Region, &info.InvoiceDetail); err != nil {
+				info.Error = err.Error()
+			}
+			if doc, err := store.getInvoiceDoc(shopID, query.OrderSN); err == nil {
+				info.IsUploaded = true
+				info.UploadTime = doc.uploadTime
+			}
+		}
+		infos = append(infos, info)
+	}
+
 	response := GetBuyerInvoiceInfoResponse{
-		RequestID: "a2c45ca2683caf1651ecab5a4d5942ce",
-		Error:     "",
-		Message:   "",
-		InvoiceInfoList: []InvoiceInfo{
-			{
-				OrderSN:     req.Queries[0].OrderSN,
-				InvoiceType: "personal",
-				InvoiceDetail: InvoiceDetail{
-					Name:        "กลวัชร หัสไทรทอง",
-					Email:       "konlawat2222@gmail.com",
-					Address:     "บ",
-					PhoneNumber: "0909573314",
-					TaxID:       "1920500012345",
-					AddressBreakdown: AddressBreakdown{
-						Region:          "Thailand",
-						State:           "จังหวัดตรัง",
-						City:            "",
-						District:        "มะกอก",
-						Town:            "ตำบลบ่อหิน",
-						Postcode:        "92150",
-						DetailedAddress: "52 หมู่ 2",
-						AdditionalInfo:  "",
-						FullAddress:     "52 หมู่ 2, ตำบลบ่อหิน, อำเภอสิเกา, จังหวัดตรัง, 92150",
-					},
-				},
-				IsRequested: false,
-				Error:       "",
-			},
-		},
+		RequestID:       newRequestID(),
+		Error:           "",
+		Message:         "",
+		InvoiceInfoList: infos,
 	}
 
 	return c.JSON(response)
@@ -451,21 +472,22 @@ func getBuyerInvoiceInfo(c *fiber.Ctx) error {
 
 // getOrderDetail retrieves order details
 // @Summary Get order details
-// @Description Retrieves detailed information about orders
+// @Description Retrieves detailed information about orders. Only order_sn, region, currency, cod, order_status, create_time, update_time, days_to_ship, ship_by_date, message_to_seller and reverse_shipping_fee are always returned; other fields, such as item_list, package_list, recipient_address, total_amount and buyer_username, only when named in response_optional_fields. Up to 50 order SNs per call; unknown SNs and other shops' orders are listed in not_found_order_sn_list
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param order_sn_list query string true "Comma-separated list of order serial numbers" example("201214JAJXU6G7,201214JASXYXY6")
+// @Param order_sn_list query string true "Comma-separated list of order serial numbers" example("2404098R48U37H,2404090000000X")
 // @Param partner_id query int64 true "Partner ID" example(123456)
 // @Param shop_id query int64 false "Shop ID" example(789012)
 // @Param timestamp query int64 true "Request timestamp" example(1640995200)
 // @Param access_token query string true "Access token" example("your_access_token")
 // @Param sign query string true "Signature" example("ABCD1234567890EFGH")
-// @Param request_order_status_pending query bool false "Request pending order status" example(true)
-// @Param response_optional_fields query string false "Optional response fields" example("total_amount")
-// @Success 200 {object} GetOrderDetailResponse "Success response"
+// @Param request_order_status_pending query bool false "Return PENDING for orders whose payment is being verified, instead of UNPAID" example(true)
+// @Param response_optional_fields query string false "Comma-separated optional fields to return" example("item_list,total_amount")
+// @Success 200 {object} OrderFieldsResponse{response=OrderFieldsListResponse{order_list=[]OrderDetail}} "Success response; only the required order fields are always present"
 // @Failure 400 {object} GetOrderDetailResponse "Bad request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} GetOrderDetailResponse "Server error"
 // @Router /api/v2/order/get_order_detail [get]
 func getOrderDetail(c *fiber.Ctx) error {
 	var req GetOrderDetailRequest
@@ -473,129 +495,71 @@ func getOrderDetail(c *fiber.Ctx) error {
 	// Parse query parameters
 	if err := c.QueryParser(&req); err != nil {
 		return c.Status(400).JSON(GetOrderDetailResponse{
-			RequestID: "",
+			RequestID: newRequestID(),
 			Error:     "invalid_request",
 			Message:   "Invalid query parameters",
 		})
 	}
 
-	if req.OrderSNList == "" {
+	// Parse comma-separated order SNs, ignoring blank entries
+	var orderSNs []string
+	for _, orderSN := range strings.Split(req.OrderSNList, ",") {
+		if orderSN = strings.TrimSpace(orderSN); orderSN != "" {
+			orderSNs = append(orderSNs, orderSN)
+		}
+	}
+
+	if len(orderSNs) == 0 {
 		return c.Status(400).JSON(GetOrderDetailResponse{
-			RequestID: "",
+			RequestID: newRequestID(),
 			Error:     "missing_order_sn_list",
 			Message:   "Order SN list is required",
 		})
 	}
 
-	// Parse comma-separated order SNs
-	orderSNs := strings.Split(req.OrderSNList, ",")
-	
-	// Create mock orders for each requested order SN
-	var orders []OrderDetail
+	if len(orderSNs) > maxOrderDetailSNs {
+		return c.Status(400).JSON(GetOrderDetailResponse{
+			RequestID: newRequestID(),
+			Error:     "error_param",
+			Message:   fmt.Sprintf("order_sn_list must contain at most %d order SNs", maxOrderDetailSNs),
+		})
+	}
+
+	// Look up each requested order SN in the shop. Unknown SNs and other
+	// shops' orders are listed as not found without failing the rest. Only
+	// the base fields and the optional ones asked for are returned, and
+	// orders whose payment is being verified show as UNPAID unless PENDING
+	// is asked for
+	shop := currentShop(c)
+	orders := []orderFields{}
+	notFound := []string{}
 	for _, orderSN := range orderSNs {
-		orderSN = strings.TrimSpace(orderSN)
-		order := OrderDetail{
-			ActualShippingFeeConfirmed: true,
-			BuyerCancelReason:          "",
-			BuyerCpfID:                 nil,
-			BuyerUserID:                121144654,
-			BuyerUsername:              "konlawatkkk",
-			CancelBy:                   "",
-			CancelReason:               "",
-			COD:                        true,
-			CreateTime:                 1758274833,
-			Currency:                   "THB",
-			DaysToShip:                 2,
-			Dropshipper:                nil,
-			DropshipperPhone:           nil,
-			EstimatedShippingFee:       5000,
-			FulfillmentFlag:            "fulfilled_by_local_seller",
-			GoodsToDeclare:             false,
-			InvoiceData:                nil,
-			ItemList: []OrderItem{
-				{
-					AddOnDeal:          false,
-					AddOnDealID:        0,
-					ImageInfo:          ImageInfo{ImageURL: "https://cf.shopee.co.th/file/th-11134207-7r98r-lo113lk51dm8c8_tn"},
-					IsB2COwnedItem:     false,
-					IsPrescriptionItem: false,
-					ItemID:             10416502727,
-					ItemName:           "อุปกรณ์บันทึกเวลางานระบบไร้สาย IOMO Smart Beacon BC-1000 ใช้งานง่ายผ่าน Application",
-					ItemSKU:            "BC-1000",
-					MainItem:           false,
-					ModelDiscountedPrice: 1090,
-					ModelID:            10416502727,
-					ModelName:          "",
-					ModelOriginalPrice: 1090,
-					ModelQuantityPurchased: 1,
-					ModelSKU:           "",
-					OrderItemID:        10416502727,
-					ProductLocationID:  []string{"THZ"},
-					PromotionGroupID:   0,
-					PromotionID:        0,
-					PromotionType:      "flash_sale",
-					Weight:             0.5,
-					Wholesale:          false,
-				},
-			},
-			MessageToSeller: "",
-			Note:            "",
-			NoteUpdateTime:  0,
-			OrderSN:         orderSN,
-			OrderStatus:     "COMPLETED",
-			PackageList: []PackageDetail{
-				{
-					GroupShipmentID: nil,
-					ItemList: []PackageItemDetail{
-						{
-							ItemID:            23620853561,
-							ModelID:           221404189791,
-							ModelQuantity:     1,
-							OrderItemID:       23620853561,
-							ProductLocationID: "VN10XX2UZ",
-							PromotionGroupID:  0,
-						},
-					},
-					LogisticsStatus:            "LOGISTICS_DELIVERY_DONE",
-					PackageNumber:              "OFG166300791210964",
-					ParcelChargeableWeightGram: 10,
-					ShippingCarrier:            "5-Day Delivery (SPX)",
-					LogisticsChannelID:         18080,
-					AllowSelfDesignAWB:         true,
-					SortingGroup:               "North",
-				},
-			},
-			PayTime:        1712817766,
-			PaymentMethod:  "Credit Card/Debit Card",
-			PickupDoneTime: 1712726577,
-			RecipientAddress: RecipientAddress{
-				City:        "เขตห้วยขวาง",
-				District:    "แขวงบางกะปิ",
-				FullAddress: "****** พระราม 9 แขวงบางกะปิ แขวงบางกะปิ เขตห้วยขวาง จังหวัดกรุงเทพมหานคร 10310",
-				Name:        "ก******ง",
-				Phone:       "******17",
-				Region:      "TH",
-				State:       "จังหวัดกรุงเทพมหานคร",
-				Town:        "",
-				Zipcode:     "10310",
-			},
-			Region:             "TH",
-			ReverseShippingFee: 0,
-			ShipByDate:         0,
-			ShippingCarrier:    "Thunder Express",
-			SplitUp:            false,	
-			TotalAmount:        1125,
-			UpdateTime:         1758274838,
+		order, ok := store.getOrder(shop.ShopID, orderSN)
+		if !ok {
+			notFound = append(notFound, orderSN)
+			continue
+		}
+		if order.OrderStatus == "PENDING" && !req.RequestOrderStatusPending {
+			order.OrderStatus = "UNPAID"
 		}
-		orders = append(orders, order)
+		fields, err := selectOrderFields(order, req.ResponseOptionalFields)
+		if err != nil {
+			return c.Status(500).JSON(GetOrderDetailResponse{
+				RequestID: newRequestID(),
+				Error:     "error_server",
+				Message:   err.Error(),
+			})
+		}
+		orders = append(orders, fields)
 	}
 
-	response := GetOrderDetailResponse{
+	response := OrderFieldsResponse{
 		Error:     "",
 		Message:   "",
-		RequestID: "023c50ace933ba38473a5fb2a7dc8821",
-		Response: OrderListResponse{
-			OrderList: orders,
+		RequestID: newRequestID(),
+		Response: OrderFieldsListResponse{
+			OrderList:           orders,
+			NotFoundOrderSNList: notFound,
 		},
 	}
 
@@ -626,173 +590,51 @@ func getItemBaseInfo(c *fiber.Ctx) error {
 	// Parse query parameters
 	if err := c.QueryParser(&req); err != nil {
 		return c.Status(400).JSON(GetItemBaseInfoResponse{
-			Error:     "invalid_request",
+			Error:     "error_param",
 			Message:   "Invalid query parameters",
 			Warning:   "",
-			RequestID: "",
+			RequestID: newRequestID(),
 		})
 	}
 
 	if req.ItemIDList == "" {
 		return c.Status(400).JSON(GetItemBaseInfoResponse{
-			Error:     "missing_item_id_list",
+			Error:     "error_param",
 			Message:   "Item ID list is required",
 			Warning:   "",
-			RequestID: "",
+			RequestID: newRequestID(),
 		})
 	}
 
-	// Create mock item details
-	var items []ItemDetail
-	
-	// For demo, create items for IDs 34001 and 34002
-	itemIDs := []int64{34001, 34002}
-	
+	itemIDs, err := parseIDList(req.ItemIDList)
+	if err != nil {
+		return c.Status(400).JSON(GetItemBaseInfoResponse{
+			Error:     "error_param",
+			Message:   "Item ID list must be a list of integers",
+			Warning:   "",
+			RequestID: newRequestID(),
+		})
+	}
+
+	// Look up each requested item in the shop's products, at the price of
+	// any ongoing discount
+	shopID := currentShop(c).ShopID
+	now := clock.Now()
+	items := []ItemDetail{}
 	for _, itemID := range itemIDs {
-		item := ItemDetail{
-			ItemID:      itemID,
-			CategoryID:  14646,
-			ItemName:    "seller discount",
-			Description: "first product 001first product",
-			ItemSKU:     "-",
-			CreateTime:  1600572637,
-			UpdateTime:  1600572640,
-			AttributeList: []Attribute{
-				{
-					AttributeID:           4811,
-					OriginalAttributeName: "Brand: L2 Default [14644]",
-					IsMandatory:           true,
-					AttributeValueList: []AttributeValue{
-						{
-							ValueID:           0,
-							OriginalValueName: "Default",
-							ValueUnit:         "g",
-						},
-					},
-				},
-			},
-			PriceInfo: []PriceInfo{
-				{
-					Currency:                     "SGD",
-					OriginalPrice:                122.02,
-					CurrentPrice:                 122.02,
-					InflatedPriceOfOriginalPrice: 222.02,
-					InflatedPriceOfCurrentPrice:  111.02,
-					SipItemPrice:                 100.02,
-					SipItemPriceSource:           "auto",
-				},
-			},
-			Image: ItemImage{
-				ImageURLList: []string{"-"},
-				ImageIDList:  []string{"-"},
-			},
-			Weight: "10.02",
-			Dimension: Dimension{
-				PackageLength: 11,
-				PackageWidth:  12,
-				PackageHeight: 13,
-			},
-			LogisticInfo: []LogisticInfo{
-				{
-					LogisticID:           80012,
-					LogisticName:         "-",
-					Enabled:              true,
-					ShippingFee:          5.02,
-					SizeID:               0,
-					IsFree:               true,
-					EstimatedShippingFee: 4.02,
-				},
-			},
-			PreOrder: PreOrder{
-				IsPreOrder: true,
-				DaysToShip: 3,
-			},
-			Wholesales: []Wholesale{
-				{
-					MinCount:                 1,
-					MaxCount:                 2,
-					UnitPrice:                4.02,
-					InflatedPriceOfUnitPrice: 5.02,
-				},
-			},
-			Condition:     "NEW/USED",
-			SizeChart:     "-",
-			ItemStatus:    "NORMAL",
-			Deboost:       "false",
-			HasModel:      true,
-			PromotionID:   13123,
-			VideoInfo: []VideoInfo{
-				{
-					VideoURL:     "-",
-					ThumbnailURL: "-",
-					Duration:     0,
-				},
-			},
-			Brand: Brand{
-				BrandID:           123,
-				OriginalBrandName: "nike",
-			},
-			ItemDangerous: 0,
-			ComplaintPolicy: ComplaintPolicy{
-				WarrantyTime:                "ONE_YEAR",
-				ExcludeEntrepreneurWarranty: true,
-				ComplaintAddressID:          0,
-				AdditionalInformation:       "-",
-			},
-			TaxInfo: TaxInfo{
-				NCM:           "-",
-				DiffStateCFOP: "-",
-				CSOSN:         "-",
-				Origin:        "-",
-				CEST:          "-",
-				MeasureUnit:   "-",
-				InvoiceOption: "-",
-				VATRate:       "-",
-				HSCode:        "-",
-				TaxCode:       "-",
-			},
-			DescriptionInfo: DescriptionInfo{
-				ExtendedDescription: ExtendedDescription{
-					FieldList: []DescriptionField{
-						{
-							FieldType: "-",
-							Text:      "-",
-							ImageInfo: DescriptionFieldImage{
-								ImageID:  "-",
-								ImageURL: "-",
-							},
-						},
-					},
-				},
-			},
-			DescriptionType: "-",
-			StockInfoV2: StockInfoV2{
-				SummaryInfo: SummaryInfo{
-					TotalReservedStock:  100,
-					TotalAvailableStock: 100,
-				},
-				SellerStock: []StockLocation{
-					{
-						LocationID: "-",
-						Stock:      10,
-					},
-				},
-				ShopeeStock: []StockLocation{
-					{
-						LocationID: "-",
-						Stock:      0,
-					},
-				},
-			},
+		item, ok := store.getItem(shopID, itemID)
+		if !ok {
+			continue
 		}
+		applyDiscount(shopID, &item, now)
 		items = append(items, item)
 	}
 
 	response := GetItemBaseInfoResponse{
-		Error:     "-",
-		Message:   "-",
-		Warning:   "-",
-		RequestID: "7b9da0c6926642199c33ee9dd3a266f5",
+		Error:     "",
+		Message:   "",
+		Warning:   "",
+		RequestID: newRequestID(),
 		Response: ItemListResponse{
 			ItemList: items,
 		},
@@ -802,26 +644,160 @@ func getItemBaseInfo(c *fiber.Ctx) error {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerateCommand(os.Args[2:]); err != nil {
+			log.Fatalf("generate: %v", err)
+		}
+		return
+	}
+
+	if path := os.Getenv("CATALOGUE_FILE"); path != "" {
+		if err := loadCatalogueFile(path); err != nil {
+			log.Fatalf("Failed to load catalogue: %v", err)
+		}
+	}
+
+	if path := os.Getenv("SCENARIO_FILE"); path != "" {
+		if err := loadScenarioFile(path); err != nil {
+			log.Fatalf("Failed to load scenarios: %v", err)
+		}
+	}
+
+	if config := proxy.getConfig(); config.Mode != proxyOff {
+		if err := proxy.setConfig(config); err != nil {
+			log.Fatalf("Failed to start proxy: %v", err)
+		}
+	}
+
 	app := fiber.New(fiber.Config{
 		AppName:         "Shopee API Mock Server",
 		ReadBufferSize:  16384,
 		WriteBufferSize: 16384,
+		BodyLimit:       maxImageSize + 1<<20,
 	})
 
 	app.Use(cors.New())
 	app.Use(logger.New())
+	app.Use(journalRequests)
+	if checkTimestamps {
+		app.Use("/api", validateTimestamp)
+	}
+	app.Use("/api", rateLimit)
+	app.Use("/api", faultInjection)
+	app.Use("/api", stubResponses)
+	app.Use("/api", proxyMiddleware)
+	app.Use("/api", scopeToTenant)
 
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
 	orderAPI := app.Group("/api/v2/order")
 	productAPI := app.Group("/api/v2/product")
+	logisticsAPI := app.Group("/api/v2/logistics")
+	mediaAPI := app.Group("/api/v2/media_space")
+	authAPI := app.Group("/api/v2/auth")
+	shopAPI := app.Group("/api/v2/shop")
+	merchantAPI := app.Group("/api/v2/merchant")
+	globalProductAPI := app.Group("/api/v2/global_product")
+	sellerChatAPI := app.Group("/api/v2/sellerchat")
+	discountAPI := app.Group("/api/v2/discount")
+	voucherAPI := app.Group("/api/v2/voucher")
+	paymentAPI := app.Group("/api/v2/payment")
+	adminAPI := app.Group("/admin")
 
-	// api.Use(validateTimestamp)
 	// api.Use(validateShopeeSignature)
 
 	orderAPI.Post("/get_buyer_invoice_info", getBuyerInvoiceInfo)
 	orderAPI.Get("/get_order_detail", getOrderDetail)
+	orderAPI.Post("/cancel_order", cancelOrder)
+	orderAPI.Post("/set_note", setNote)
+	orderAPI.Post("/upload_invoice_doc", uploadInvoiceDoc)
+	orderAPI.Get("/download_invoice_doc", downloadInvoiceDoc)
 	productAPI.Get("/get_item_base_info", getItemBaseInfo)
+	productAPI.Get("/get_category", getCategory)
+	productAPI.Get("/get_attributes", getAttributes)
+	productAPI.Get("/get_brand_list", getBrandList)
+	productAPI.Post("/add_item", addItem)
+	productAPI.Post("/update_item", updateItem)
+	logisticsAPI.Post("/ship_order", shipOrder)
+	mediaAPI.Post("/upload_image", uploadImage)
+	mediaAPI.Post("/init_video_upload", initVideoUpload)
+	mediaAPI.Post("/upload_video_part", uploadVideoPart)
+	mediaAPI.Post("/complete_video_upload", completeVideoUpload)
+	mediaAPI.Get("/get_video_upload_result", getVideoUploadResult)
+	authAPI.Post("/token/get", getAccessToken)
+	authAPI.Post("/access_token/get", refreshAccessToken)
+	shopAPI.Get("/get_shop_info", getShopInfo)
+	merchantAPI.Get("/get_shop_list_by_merchant", getShopListByMerchant)
+	globalProductAPI.Post("/add_global_item", addGlobalItem)
+	globalProductAPI.Get("/get_global_item_info", getGlobalItemInfo)
+	sellerChatAPI.Get("/get_conversation_list", getConversationList)
+	sellerChatAPI.Get("/get_message", getMessage)
+	sellerChatAPI.Post("/send_message", sendMessage)
+	sellerChatAPI.Post("/read_conversation", readConversation)
+	sellerChatAPI.Post("/upload_image", uploadChatImage)
+	discountAPI.Post("/add_discount", addDiscount)
+	discountAPI.Post("/add_discount_item", addDiscountItem)
+	discountAPI.Get("/get_discount", getDiscount)
+	discountAPI.Get("/get_discount_list", getDiscountList)
+	discountAPI.Post("/end_discount", endDiscount)
+	voucherAPI.Post("/add_voucher", addVoucher)
+	voucherAPI.Post("/update_voucher", updateVoucher)
+	voucherAPI.Post("/end_voucher", endVoucher)
+	voucherAPI.Get("/get_voucher_list", getVoucherList)
+	paymentAPI.Get("/get_escrow_detail", getEscrowDetail)
+
+	app.Get("/media/images/:image_id", serveImage)
+	app.Get("/media/videos/:video_upload_id", serveVideo)
+
+	adminAPI.Post("/orders", adminCreateOrder)
+	adminAPI.Put("/orders/:order_sn/invoice", adminSetInvoice)
+	adminAPI.Delete("/orders/:order_sn/invoice", adminDeleteInvoice)
+	adminAPI.Get("/invoice_settings", adminGetInvoiceSettings)
+	adminAPI.Put("/invoice_settings", adminSetInvoiceSettings)
+	adminAPI.Post("/chat/messages", adminInjectChatMessage)
+	adminAPI.Put("/catalogue", adminLoadCatalogue)
+	adminAPI.Get("/webhook", adminGetWebhook)
+	adminAPI.Put("/webhook", adminSetWebhook)
+	adminAPI.Get("/webhook/deliveries", adminListDeliveries)
+	adminAPI.Get("/webhook/deliveries/:id", adminGetDelivery)
+	adminAPI.Post("/webhook/deliveries/:id/redeliver", adminRedeliver)
+	adminAPI.Post("/webhook/replay", adminReplayDeliveries)
+	adminAPI.Get("/faults", adminListFaults)
+	adminAPI.Post("/faults", adminAddFault)
+	adminAPI.Delete("/faults", adminClearFaults)
+	adminAPI.Put("/faults/:id", adminUpdateFault)
+	adminAPI.Delete("/faults/:id", adminDeleteFault)
+	adminAPI.Get("/clock", adminGetClock)
+	adminAPI.Put("/clock", adminSetClock)
+	adminAPI.Post("/clock/advance", adminAdvanceClock)
+	adminAPI.Post("/clock/freeze", adminFreezeClock)
+	adminAPI.Post("/clock/resume", adminResumeClock)
+	adminAPI.Get("/scenarios", adminListScenarios)
+	adminAPI.Post("/scenarios", adminStartScenario)
+	adminAPI.Get("/scenarios/:id", adminGetScenario)
+	adminAPI.Delete("/scenarios/:id", adminCancelScenario)
+	adminAPI.Get("/proxy", adminGetProxy)
+	adminAPI.Put("/proxy", adminSetProxy)
+	adminAPI.Get("/proxy/fixtures", adminListFixtures)
+	adminAPI.Get("/stubs", adminListStubs)
+	adminAPI.Post("/stubs", adminAddStub)
+	adminAPI.Delete("/stubs", adminClearStubs)
+	adminAPI.Put("/stubs/:id", adminUpdateStub)
+	adminAPI.Delete("/stubs/:id", adminDeleteStub)
+	adminAPI.Get("/requests", adminListRequests)
+	adminAPI.Delete("/requests", adminClearRequests)
+	adminAPI.Get("/rate_limits", adminListRateLimits)
+	adminAPI.Post("/rate_limits", adminAddRateLimit)
+	adminAPI.Delete("/rate_limits", adminClearRateLimits)
+	adminAPI.Put("/rate_limits/:id", adminUpdateRateLimit)
+	adminAPI.Delete("/rate_limits/:id", adminDeleteRateLimit)
+	adminAPI.Get("/shops", adminListShops)
+	adminAPI.Post("/shops", adminAddShop)
+	adminAPI.Get("/merchants", adminListMerchants)
+	adminAPI.Post("/merchants", adminAddMerchant)
+	adminAPI.Post("/tokens", adminIssueToken)
+	adminAPI.Get("/regions", adminListRegions)
+	adminAPI.Post("/generate", adminGenerateData)
 
 	log.Println("Starting server on :3001")
 	log.Fatal(app.Listen(":3001"))
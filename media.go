@@ -0,0 +1,649 @@
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	placeholderImageID = "th-11134207-placeholder"
+	maxImageSize       = 10 << 20
+	maxVideoPartSize   = 4 << 20
+	maxVideoSize       = 30 << 20
+)
+
+var (
+	errImageNotFound       = errors.New("image not found")
+	errVideoUploadNotFound = errors.New("video upload not found")
+	errVideoNotReady       = errors.New("video upload has not succeeded")
+)
+
+type ImageURL struct {
+	ImageURLRegion string `json:"image_url_region" example:"TH"`
+	ImageURL       string `json:"image_url" example:"http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"`
+}
+
+type UploadedImageInfo struct {
+	ImageID      string     `json:"image_id" example:"th-11134201-7r98o-lxyz12ab34cd56"`
+	ImageURLList []ImageURL `json:"image_url_list"`
+}
+
+type UploadImageResult struct {
+	ImageInfo UploadedImageInfo `json:"image_info"`
+}
+
+type UploadImageResponse struct {
+	RequestID string             `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string             `json:"error" example:""`
+	Message   string             `json:"message" example:""`
+	Response  *UploadImageResult `json:"response,omitempty"`
+}
+
+type InitVideoUploadRequest struct {
+	FileMD5  string `json:"file_md5" example:"2a8ac3c8c4b1d1e16a9d2c7f1b4ef5a1"`
+	FileSize int64  `json:"file_size" example:"1261924"`
+}
+
+type InitVideoUploadResult struct {
+	VideoUploadID string `json:"video_upload_id" example:"th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+}
+
+type InitVideoUploadResponse struct {
+	RequestID string                 `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string                 `json:"error" example:""`
+	Message   string                 `json:"message" example:""`
+	Response  *InitVideoUploadResult `json:"response,omitempty"`
+}
+
+type CompleteVideoUploadReport struct {
+	UploadCost int64 `json:"upload_cost" example:"11832"`
+}
+
+type CompleteVideoUploadRequest struct {
+	VideoUploadID string                    `json:"video_upload_id" example:"th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+	PartSeqList   []int                     `json:"part_seq_list" example:"0,1"`
+	ReportData    CompleteVideoUploadReport `json:"report_data"`
+}
+
+type MediaSpaceResponse struct {
+	RequestID string `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string `json:"error" example:""`
+	Message   string `json:"message" example:""`
+}
+
+type VideoURL struct {
+	VideoURLRegion string `json:"video_url_region" example:"TH"`
+	VideoURL       string `json:"video_url" example:"http://localhost:3001/media/videos/th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+}
+
+type UploadedVideoInfo struct {
+	VideoURLList     []VideoURL `json:"video_url_list"`
+	ThumbnailURLList []ImageURL `json:"thumbnail_url_list"`
+	Duration         int        `json:"duration" example:"0"`
+}
+
+type VideoUploadResult struct {
+	Status    string             `json:"status" example:"SUCCEEDED"`
+	VideoInfo *UploadedVideoInfo `json:"video_info,omitempty"`
+	Message   string             `json:"message" example:""`
+}
+
+type GetVideoUploadResultResponse struct {
+	RequestID string             `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string             `json:"error" example:""`
+	Message   string             `json:"message" example:""`
+	Response  *VideoUploadResult `json:"response,omitempty"`
+}
+
+// videoUpload tracks one multi-part video upload from init to completion.
+type videoUpload struct {
+	shop     Shop
+	fileMD5  string
+	fileSize int64
+	parts    map[int]string
+	status   string
+	message  string
+}
+
+// mediaStore keeps uploaded images and videos on local disk and hands out
+// URLs under baseURL that the server serves back.
+type mediaStore struct {
+	mu      sync.Mutex
+	dir     string
+	baseURL string
+	images  map[string]string
+	videos  map[string]*videoUpload
+}
+
+var media = newMediaStore(getEnv("MEDIA_DIR", "media"), getEnv("MEDIA_BASE_URL", "http://localhost:3001"))
+
+func newMediaStore(dir, baseURL string) *mediaStore {
+	return &mediaStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		images:  make(map[string]string),
+		videos:  make(map[string]*videoUpload),
+	}
+}
+
+func (m *mediaStore) imageURL(imageID string) string {
+	return m.baseURL + "/media/images/" + imageID
+}
+
+func (m *mediaStore) videoURL(videoUploadID string) string {
+	return m.baseURL + "/media/videos/" + videoUploadID
+}
+
+// saveImage writes an uploaded image to disk and returns its new image ID.
+func (m *mediaStore) saveImage(data []byte, ext string) (string, error) {
+	imageID := "th-11134201-" + randomString(5) + "-" + randomString(14)
+	path := filepath.Join(m.dir, "images", imageID+ext)
+	if err := writeFile(path, data); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.images[imageID] = path
+	return imageID, nil
+}
+
+// imageURLs resolves image IDs to their URLs, failing on IDs that were never
+// uploaded.
+func (m *mediaStore) imageURLs(imageIDs []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	urls := make([]string, 0, len(imageIDs))
+	for _, imageID := range imageIDs {
+		if _, ok := m.images[imageID]; !ok && imageID != placeholderImageID {
+			return nil, fmt.Errorf("%w: %s", errImageNotFound, imageID)
+		}
+		urls = append(urls, m.imageURL(imageID))
+	}
+	return urls, nil
+}
+
+// initVideo starts a video upload for a shop, whose region the video's URLs
+// are given and to which the result is pushed.
+func (m *mediaStore) initVideo(shop Shop, fileMD5 string, fileSize int64) string {
+	videoUploadID := "th_" + randomString(32) + "_000000"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.videos[videoUploadID] = &videoUpload{
+		shop:     shop,
+		fileMD5:  strings.ToLower(fileMD5),
+		fileSize: fileSize,
+		parts:    make(map[int]string),
+		status:   "INITIATED",
+	}
+	return videoUploadID
+}
+
+func (m *mediaStore) saveVideoPart(videoUploadID string, partSeq int, data []byte) error {
+	m.mu.Lock()
+	upload, ok := m.videos[videoUploadID]
+	if ok && upload.status != "INITIATED" {
+		m.mu.Unlock()
+		return fmt.Errorf("video upload is %s", upload.status)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return errVideoUploadNotFound
+	}
+
+	path := filepath.Join(m.dir, "videos", videoUploadID+".parts", fmt.Sprintf("%d", partSeq))
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upload.parts[partSeq] = path
+	return nil
+}
+
+// completeVideo joins the uploaded parts in sequence order and checks the
+// result against the size and MD5 given at init. A mismatch fails the
+// upload rather than the request, as Shopee reports it through
+// get_video_upload_result.
+func (m *mediaStore) completeVideo(videoUploadID string, partSeqList []int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, ok := m.videos[videoUploadID]
+	if !ok {
+		return errVideoUploadNotFound
+	}
+	if upload.status != "INITIATED" {
+		return fmt.Errorf("video upload is %s", upload.status)
+	}
+
+	seqs := append([]int(nil), partSeqList...)
+	sort.Ints(seqs)
+	var video bytes.Buffer
+	for _, seq := range seqs {
+		path, ok := upload.parts[seq]
+		if !ok {
+			return fmt.Errorf("part_seq %d was not uploaded", seq)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		video.Write(data)
+	}
+
+	sum := md5.Sum(video.Bytes())
+	switch {
+	case int64(video.Len()) != upload.fileSize:
+		upload.status = "FAILED"
+		upload.message = fmt.Sprintf("file size %d does not match file_size %d", video.Len(), upload.fileSize)
+	case upload.fileMD5 != "" && hex.EncodeToString(sum[:]) != upload.fileMD5:
+		upload.status = "FAILED"
+		upload.message = "file md5 does not match file_md5"
+	default:
+		if err := writeFile(filepath.Join(m.dir, "videos", videoUploadID+".mp4"), video.Bytes()); err != nil {
+			return err
+		}
+		upload.status = "SUCCEEDED"
+	}
+	os.RemoveAll(filepath.Join(m.dir, "videos", videoUploadID+".parts"))
+
+	push := VideoUploadPush{
+		VideoUploadID: videoUploadID,
+		Status:        upload.status,
+		Message:       upload.message,
+	}
+	if upload.status == "SUCCEEDED" {
+		push.VideoInfo = m.uploadedVideoInfo(videoUploadID, upload.shop.Region)
+	}
+	webhooks.push(pushVideoUpload, upload.shop.ShopID, push)
+	return nil
+}
+
+func (m *mediaStore) videoResult(videoUploadID string) (VideoUploadResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, ok := m.videos[videoUploadID]
+	if !ok {
+		return VideoUploadResult{}, errVideoUploadNotFound
+	}
+
+	result := VideoUploadResult{Status: upload.status, Message: upload.message}
+	if upload.status == "SUCCEEDED" {
+		result.VideoInfo = m.uploadedVideoInfo(videoUploadID, upload.shop.Region)
+	}
+	return result, nil
+}
+
+func (m *mediaStore) uploadedVideoInfo(videoUploadID, region string) *UploadedVideoInfo {
+	return &UploadedVideoInfo{
+		VideoURLList:     []VideoURL{{VideoURLRegion: region, VideoURL: m.videoURL(videoUploadID)}},
+		ThumbnailURLList: []ImageURL{{ImageURLRegion: region, ImageURL: m.imageURL(placeholderImageID)}},
+	}
+}
+
+// videoInfo returns the item video entry for a completed upload.
+func (m *mediaStore) videoInfo(videoUploadID string) (VideoInfo, error) {
+	result, err := m.videoResult(videoUploadID)
+	if err != nil {
+		return VideoInfo{}, err
+	}
+	if result.VideoInfo == nil {
+		return VideoInfo{}, fmt.Errorf("%w: %s", errVideoNotReady, videoUploadID)
+	}
+	return VideoInfo{
+		VideoURL:     result.VideoInfo.VideoURLList[0].VideoURL,
+		ThumbnailURL: result.VideoInfo.ThumbnailURLList[0].ImageURL,
+		Duration:     result.VideoInfo.Duration,
+	}, nil
+}
+
+func (m *mediaStore) imagePath(imageID string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	path, ok := m.images[imageID]
+	return path, ok
+}
+
+func (m *mediaStore) videoPath(videoUploadID string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upload, ok := m.videos[videoUploadID]
+	if !ok || upload.status != "SUCCEEDED" {
+		return "", false
+	}
+	return filepath.Join(m.dir, "videos", videoUploadID+".mp4"), true
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func readFormFile(c *fiber.Ctx, field string, limit int64) ([]byte, error) {
+	header, err := c.FormFile(field)
+	if err != nil {
+		return nil, fmt.Errorf("%s file is required", field)
+	}
+	if header.Size > limit {
+		return nil, fmt.Errorf("%s exceeds %d bytes", field, limit)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// placeholderPNG renders the grey square served for placeholderImageID.
+func placeholderPNG() []byte {
+	img := image.NewGray(image.Rect(0, 0, 100, 100))
+	for i := range img.Pix {
+		img.Pix[i] = color.Gray{Y: 0xee}.Y
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// uploadImage uploads an image to the media space
+// @Summary Upload image
+// @Description Stores a JPG or PNG image and returns its image_id and URL
+// @Tags MediaSpace
+// @Accept multipart/form-data
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID the upload is for" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param image formData file true "Image file, at most 10 MB"
+// @Param scene formData string false "Upload scene, normal or desc" example("normal")
+// @Success 200 {object} UploadImageResponse "Success response"
+// @Failure 400 {object} UploadImageResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/media_space/upload_image [post]
+func uploadImage(c *fiber.Ctx) error {
+	data, err := readFormFile(c, "image", maxImageSize)
+	if err != nil {
+		return c.Status(400).JSON(UploadImageResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	var ext string
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	default:
+		return c.Status(400).JSON(UploadImageResponse{
+			Error:   "error_param",
+			Message: "image must be a JPG or PNG file",
+		})
+	}
+
+	imageID, err := media.saveImage(data, ext)
+	if err != nil {
+		return c.Status(500).JSON(UploadImageResponse{
+			Error:   "error_server",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(UploadImageResponse{
+		RequestID: newRequestID(),
+		Response: &UploadImageResult{
+			ImageInfo: UploadedImageInfo{
+				ImageID:      imageID,
+				ImageURLList: []ImageURL{{ImageURLRegion: currentShop(c).Region, ImageURL: media.imageURL(imageID)}},
+			},
+		},
+	})
+}
+
+// initVideoUpload starts a multi-part video upload
+// @Summary Init video upload
+// @Description Starts a multi-part video upload for a file of the given size and MD5
+// @Tags MediaSpace
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID the upload is for" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body InitVideoUploadRequest true "Video file to upload"
+// @Success 200 {object} InitVideoUploadResponse "Success response"
+// @Failure 400 {object} InitVideoUploadResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/media_space/init_video_upload [post]
+func initVideoUpload(c *fiber.Ctx) error {
+	var req InitVideoUploadRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(InitVideoUploadResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.FileSize <= 0 || req.FileSize > maxVideoSize {
+		return c.Status(400).JSON(InitVideoUploadResponse{
+			Error:   "error_param",
+			Message: fmt.Sprintf("file_size must be between 1 and %d bytes", maxVideoSize),
+		})
+	}
+
+	return c.JSON(InitVideoUploadResponse{
+		RequestID: newRequestID(),
+		Response: &InitVideoUploadResult{
+			VideoUploadID: media.initVideo(currentShop(c), req.FileMD5, req.FileSize),
+		},
+	})
+}
+
+// uploadVideoPart uploads one part of a video
+// @Summary Upload video part
+// @Description Stores one part of a multi-part video upload, checking its MD5 when given
+// @Tags MediaSpace
+// @Accept multipart/form-data
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param video_upload_id formData string true "Video upload ID from init_video_upload"
+// @Param part_seq formData int true "Zero-based part sequence number" example(0)
+// @Param content_md5 formData string false "MD5 of the part content"
+// @Param part_content formData file true "Part content, at most 4 MB"
+// @Success 200 {object} MediaSpaceResponse "Success response"
+// @Failure 400 {object} MediaSpaceResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/media_space/upload_video_part [post]
+func uploadVideoPart(c *fiber.Ctx) error {
+	videoUploadID := c.FormValue("video_upload_id")
+	var partSeq int
+	if _, err := fmt.Sscan(c.FormValue("part_seq"), &partSeq); err != nil || partSeq < 0 {
+		return c.Status(400).JSON(MediaSpaceResponse{
+			Error:   "error_param",
+			Message: "part_seq must be a non-negative integer",
+		})
+	}
+
+	data, err := readFormFile(c, "part_content", maxVideoPartSize)
+	if err != nil {
+		return c.Status(400).JSON(MediaSpaceResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	if contentMD5 := c.FormValue("content_md5"); contentMD5 != "" {
+		sum := md5.Sum(data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), contentMD5) {
+			return c.Status(400).JSON(MediaSpaceResponse{
+				Error:   "error_param",
+				Message: "content_md5 does not match part_content",
+			})
+		}
+	}
+
+	if err := media.saveVideoPart(videoUploadID, partSeq, data); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(MediaSpaceResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(MediaSpaceResponse{RequestID: newRequestID()})
+}
+
+// completeVideoUpload finishes a multi-part video upload
+// @Summary Complete video upload
+// @Description Joins the uploaded parts into the final video and verifies its size and MD5
+// @Tags MediaSpace
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body CompleteVideoUploadRequest true "Parts to join"
+// @Success 200 {object} MediaSpaceResponse "Success response"
+// @Failure 400 {object} MediaSpaceResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/media_space/complete_video_upload [post]
+func completeVideoUpload(c *fiber.Ctx) error {
+	var req CompleteVideoUploadRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(MediaSpaceResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if len(req.PartSeqList) == 0 {
+		return c.Status(400).JSON(MediaSpaceResponse{
+			Error:   "error_param",
+			Message: "part_seq_list is required",
+		})
+	}
+
+	if err := media.completeVideo(req.VideoUploadID, req.PartSeqList); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(MediaSpaceResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(MediaSpaceResponse{RequestID: newRequestID()})
+}
+
+// getVideoUploadResult reports the state of a video upload
+// @Summary Get video upload result
+// @Description Reports whether a video upload succeeded and, if so, its video and thumbnail URLs
+// @Tags MediaSpace
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param video_upload_id query string true "Video upload ID from init_video_upload"
+// @Success 200 {object} GetVideoUploadResultResponse "Success response"
+// @Failure 404 {object} GetVideoUploadResultResponse "Unknown upload"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/media_space/get_video_upload_result [get]
+func getVideoUploadResult(c *fiber.Ctx) error {
+	result, err := media.videoResult(c.Query("video_upload_id"))
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(GetVideoUploadResultResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(GetVideoUploadResultResponse{
+		RequestID: newRequestID(),
+		Response:  &result,
+	})
+}
+
+// serveImage serves an uploaded image
+// @Summary Serve image
+// @Description Serves an image previously uploaded through upload_image
+// @Tags MediaSpace
+// @Produce image/jpeg
+// @Produce image/png
+// @Param image_id path string true "Image ID"
+// @Success 200 {file} file "Image"
+// @Failure 404 {object} map[string]interface{} "Unknown image"
+// @Router /media/images/{image_id} [get]
+func serveImage(c *fiber.Ctx) error {
+	imageID := c.Params("image_id")
+	if imageID == placeholderImageID {
+		c.Set(fiber.HeaderContentType, "image/png")
+		return c.Send(placeholderPNG())
+	}
+
+	path, ok := media.imagePath(imageID)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error":   "error_not_found",
+			"message": "Image not found",
+		})
+	}
+	return c.SendFile(path)
+}
+
+// serveVideo serves an uploaded video
+// @Summary Serve video
+// @Description Serves a video whose multi-part upload has succeeded
+// @Tags MediaSpace
+// @Produce video/mp4
+// @Param video_upload_id path string true "Video upload ID"
+// @Success 200 {file} file "Video"
+// @Failure 404 {object} map[string]interface{} "Unknown video"
+// @Router /media/videos/{video_upload_id} [get]
+func serveVideo(c *fiber.Ctx) error {
+	path, ok := media.videoPath(c.Params("video_upload_id"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error":   "error_not_found",
+			"message": "Video not found",
+		})
+	}
+	c.Set(fiber.HeaderContentType, "video/mp4")
+	return c.SendFile(path)
+}
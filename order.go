@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxOrderDetailSNs is the most order SNs get_order_detail accepts in one
+// call.
+const maxOrderDetailSNs = 50
+
+// baseOrderFields are the order fields get_order_detail always returns.
+// Every other field is only returned when named in response_optional_fields.
+var baseOrderFields = []string{
+	"order_sn",
+	"region",
+	"currency",
+	"cod",
+	"order_status",
+	"create_time",
+	"update_time",
+	"days_to_ship",
+	"ship_by_date",
+	"message_to_seller",
+	"reverse_shipping_fee",
+}
+
+// orderFields is an order cut down to the fields a caller asked for.
+type orderFields map[string]json.RawMessage
+
+type OrderFieldsListResponse struct {
+	OrderList           []orderFields `json:"order_list" swaggertype:"array,object"`
+	NotFoundOrderSNList []string      `json:"not_found_order_sn_list"`
+}
+
+type OrderFieldsResponse struct {
+	Error     string                  `json:"error"`
+	Message   string                  `json:"message"`
+	RequestID string                  `json:"request_id"`
+	Response  OrderFieldsListResponse `json:"response"`
+}
+
+// selectOrderFields keeps an order's base fields and the comma-separated
+// optional fields requested. Unknown names are ignored.
+func selectOrderFields(order OrderDetail, optional string) (orderFields, error) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+	var all orderFields
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	fields := orderFields{}
+	for _, name := range baseOrderFields {
+		fields[name] = all[name]
+	}
+	for _, name := range strings.Split(optional, ",") {
+		name = strings.TrimSpace(name)
+		if value, ok := all[name]; ok {
+			fields[name] = value
+		}
+	}
+	return fields, nil
+}
+
+type CancelOrderItem struct {
+	ItemID  int64 `json:"item_id" example:"34001"`
+	ModelID int64 `json:"model_id" example:"0"`
+}
+
+type CancelOrderRequest struct {
+	OrderSN      string            `json:"order_sn" example:"2404098R48U37H"`
+	CancelReason string            `json:"cancel_reason" example:"OUT_OF_STOCK"`
+	ItemList     []CancelOrderItem `json:"item_list"`
+}
+
+type CancelOrderResult struct {
+	UpdateTime int64 `json:"update_time" example:"1758274838"`
+}
+
+type CancelOrderResponse struct {
+	RequestID string            `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string            `json:"error" example:""`
+	Message   string            `json:"message" example:""`
+	Response  CancelOrderResult `json:"response"`
+}
+
+// cancelOrder cancels an order on behalf of the seller
+// @Summary Cancel order
+// @Description Cancels an order that has not shipped yet and releases its reserved stock
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body CancelOrderRequest true "Order to cancel"
+// @Success 200 {object} CancelOrderResponse "Success response"
+// @Failure 400 {object} CancelOrderResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/order/cancel_order [post]
+func cancelOrder(c *fiber.Ctx) error {
+	var req CancelOrderRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(CancelOrderResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.OrderSN == "" {
+		return c.Status(400).JSON(CancelOrderResponse{
+			Error:   "error_param",
+			Message: "order_sn is required",
+		})
+	}
+
+	order, err := store.cancelOrder(currentShop(c).ShopID, req.OrderSN, "seller", req.CancelReason, clock.Now())
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(CancelOrderResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(CancelOrderResponse{
+		RequestID: newRequestID(),
+		Response: CancelOrderResult{
+			UpdateTime: order.UpdateTime,
+		},
+	})
+}
+
+type SetNoteRequest struct {
+	OrderSN string `json:"order_sn" example:"2404098R48U37H"`
+	Note    string `json:"note" example:"Gift wrap requested by phone"`
+}
+
+type SetNoteResponse struct {
+	RequestID string `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string `json:"error" example:""`
+	Message   string `json:"message" example:""`
+}
+
+// setNote sets the seller's note on an order
+// @Summary Set order note
+// @Description Replaces the seller's note on an order; get_order_detail returns it with its note_update_time
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body SetNoteRequest true "Order and note"
+// @Success 200 {object} SetNoteResponse "Success response"
+// @Failure 400 {object} SetNoteResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/order/set_note [post]
+func setNote(c *fiber.Ctx) error {
+	var req SetNoteRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(SetNoteResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.OrderSN == "" {
+		return c.Status(400).JSON(SetNoteResponse{
+			Error:   "error_param",
+			Message: "order_sn is required",
+		})
+	}
+
+	if _, err := store.setNote(currentShop(c).ShopID, req.OrderSN, req.Note, clock.Now()); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(SetNoteResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(SetNoteResponse{RequestID: newRequestID()})
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetOrderDetailSNList(t *testing.T) {
+	tests := []struct {
+		name         string
+		orderSNList  string
+		wantStatus   int
+		wantError    string
+		wantFound    int
+		wantNotFound []string
+	}{
+		{
+			name:        "sample order",
+			orderSNList: "2404098R48U37H",
+			wantStatus:  200,
+			wantFound:   1,
+		},
+		{
+			name:         "blank entries are ignored",
+			orderSNList:  " 2404098R48U37H ,, UNKNOWN,",
+			wantStatus:   200,
+			wantFound:    1,
+			wantNotFound: []string{"UNKNOWN"},
+		},
+		{
+			name:        "only blank entries",
+			orderSNList: " , ,",
+			wantStatus:  400,
+			wantError:   "missing_order_sn_list",
+		},
+		{
+			name:         "limit counts non-blank entries",
+			orderSNList:  strings.Repeat("A,", maxOrderDetailSNs) + strings.Repeat(",", 10),
+			wantStatus:   200,
+			wantNotFound: strings.Split(strings.Repeat(",A", maxOrderDetailSNs)[1:], ","),
+		},
+		{
+			name:        "over the limit",
+			orderSNList: strings.Repeat("A,", maxOrderDetailSNs) + "B",
+			wantStatus:  400,
+			wantError:   "error_param",
+		},
+	}
+
+	app := newTenancyTestApp()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/api/v2/order/get_order_detail?shop_id=789012&access_token=your_access_token&order_sn_list=" + url.QueryEscape(tt.orderSNList)
+			resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body struct {
+				Error     string `json:"error"`
+				RequestID string `json:"request_id"`
+				Response  struct {
+					OrderList           []map[string]interface{} `json:"order_list"`
+					NotFoundOrderSNList []string                 `json:"not_found_order_sn_list"`
+				} `json:"response"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus || body.Error != tt.wantError {
+				t.Fatalf("status = %d, error = %q, want %d, %q", resp.StatusCode, body.Error, tt.wantStatus, tt.wantError)
+			}
+			if len(body.RequestID) != 32 || body.RequestID == "023c50ace933ba38473a5fb2a7dc8821" {
+				t.Errorf("request_id = %q, want a fresh ID", body.RequestID)
+			}
+			if len(body.Response.OrderList) != tt.wantFound {
+				t.Errorf("found %d orders, want %d", len(body.Response.OrderList), tt.wantFound)
+			}
+			if strings.Join(body.Response.NotFoundOrderSNList, ",") != strings.Join(tt.wantNotFound, ",") {
+				t.Errorf("not_found_order_sn_list = %q, want %q", body.Response.NotFoundOrderSNList, tt.wantNotFound)
+			}
+		})
+	}
+}
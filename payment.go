@@ -0,0 +1,126 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// The mock charges every order the same fee rates, whatever its region or
+// the seller's programmes.
+const (
+	commissionFeeRate  = 0.05
+	transactionFeeRate = 0.02
+)
+
+type OrderIncome struct {
+	EscrowAmount         float64  `json:"escrow_amount" example:"102.15"`
+	BuyerTotalAmount     float64  `json:"buyer_total_amount" example:"112.02"`
+	OriginalPrice        float64  `json:"original_price" example:"122.02"`
+	SellerDiscount       float64  `json:"seller_discount" example:"0"`
+	ShopeeDiscount       float64  `json:"shopee_discount" example:"0"`
+	VoucherFromSeller    float64  `json:"voucher_from_seller" example:"0"`
+	VoucherFromShopee    float64  `json:"voucher_from_shopee" example:"10"`
+	Coins                float64  `json:"coins" example:"0"`
+	BuyerPaidShippingFee float64  `json:"buyer_paid_shipping_fee" example:"0"`
+	EstimatedShippingFee float64  `json:"estimated_shipping_fee" example:"0"`
+	CommissionFee        float64  `json:"commission_fee" example:"6.1"`
+	ServiceFee           float64  `json:"service_fee" example:"0"`
+	SellerTransactionFee float64  `json:"seller_transaction_fee" example:"2.24"`
+	SellerVoucherCode    []string `json:"seller_voucher_code"`
+}
+
+type EscrowDetail struct {
+	OrderSN       string      `json:"order_sn" example:"2404098R48U37H"`
+	BuyerUserName string      `json:"buyer_user_name" example:"konlawatkkk"`
+	OrderIncome   OrderIncome `json:"order_income"`
+}
+
+type GetEscrowDetailResponse struct {
+	RequestID string        `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string        `json:"error" example:""`
+	Message   string        `json:"message" example:""`
+	Response  *EscrowDetail `json:"response,omitempty"`
+}
+
+// orderIncome breaks an order's payment down into what the buyer paid, who
+// funded its discounts and what the seller is paid out. Seller vouchers
+// come out of the seller's escrow; Shopee vouchers do not.
+func orderIncome(order OrderDetail) OrderIncome {
+	profile := regionProfile(order.Region)
+
+	var original, merchandise float64
+	for _, item := range order.ItemList {
+		quantity := float64(item.ModelQuantityPurchased)
+		price := item.ModelOriginalPrice
+		if price == 0 {
+			price = item.ModelDiscountedPrice
+		}
+		original += price * quantity
+		merchandise += item.ModelDiscountedPrice * quantity
+	}
+
+	income := OrderIncome{
+		BuyerTotalAmount:     order.TotalAmount,
+		OriginalPrice:        profile.price(original),
+		SellerDiscount:       profile.price(original - merchandise),
+		VoucherFromSeller:    order.VoucherFromSeller,
+		VoucherFromShopee:    order.VoucherFromShopee,
+		EstimatedShippingFee: order.EstimatedShippingFee,
+		SellerVoucherCode:    []string{},
+	}
+	if order.VoucherCode != "" {
+		income.SellerVoucherCode = []string{order.VoucherCode}
+	}
+	// Whatever the buyer paid beyond the merchandise after vouchers went
+	// on shipping
+	income.BuyerPaidShippingFee = profile.price(max(0, order.TotalAmount-(merchandise-order.VoucherFromSeller-order.VoucherFromShopee)))
+
+	sellerRevenue := merchandise - order.VoucherFromSeller
+	income.CommissionFee = profile.price(sellerRevenue * commissionFeeRate)
+	income.SellerTransactionFee = profile.price(order.TotalAmount * transactionFeeRate)
+	income.EscrowAmount = profile.price(sellerRevenue - income.CommissionFee - income.ServiceFee - income.SellerTransactionFee)
+	return income
+}
+
+// getEscrowDetail returns the payment breakdown of an order
+// @Summary Get escrow detail
+// @Description Returns what the buyer paid for an order, the seller and Shopee discounts and vouchers, the fees, and the escrow amount paid out to the seller. Vouchers from the seller are deducted from the escrow; vouchers from Shopee are not. The mock charges a 5% commission on the merchandise after seller vouchers and a 2% transaction fee on the buyer's payment.
+// @Tags Payment
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param order_sn query string true "Order SN" example("2404098R48U37H")
+// @Success 200 {object} GetEscrowDetailResponse "Success response"
+// @Failure 400 {object} GetEscrowDetailResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} GetEscrowDetailResponse "Unknown order"
+// @Router /api/v2/payment/get_escrow_detail [get]
+func getEscrowDetail(c *fiber.Ctx) error {
+	orderSN := c.Query("order_sn")
+	if orderSN == "" {
+		return c.Status(400).JSON(GetEscrowDetailResponse{
+			Error:   "error_param",
+			Message: "order_sn is required",
+		})
+	}
+
+	order, ok := store.getOrder(currentShop(c).ShopID, orderSN)
+	if !ok {
+		return c.Status(404).JSON(GetEscrowDetailResponse{
+			RequestID: newRequestID(),
+			Error:     "error_not_found",
+			Message:   errOrderNotFound.Error(),
+		})
+	}
+
+	return c.JSON(GetEscrowDetailResponse{
+		RequestID: newRequestID(),
+		Response: &EscrowDetail{
+			OrderSN:       order.OrderSN,
+			BuyerUserName: order.BuyerUsername,
+			OrderIncome:   orderIncome(order),
+		},
+	})
+}
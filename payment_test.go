@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderIncome(t *testing.T) {
+	tests := []struct {
+		name  string
+		order OrderDetail
+		want  OrderIncome
+	}{
+		{
+			name: "seller and Shopee vouchers",
+			order: OrderDetail{
+				Region:            "TH",
+				TotalAmount:       107.02,
+				ItemList:          []OrderItem{{ModelQuantityPurchased: 1, ModelDiscountedPrice: 122.02}},
+				VoucherCode:       "SHOP10",
+				VoucherFromSeller: 10,
+				VoucherFromShopee: 5,
+			},
+			want: OrderIncome{
+				EscrowAmount:         104.28,
+				BuyerTotalAmount:     107.02,
+				OriginalPrice:        122.02,
+				VoucherFromSeller:    10,
+				VoucherFromShopee:    5,
+				CommissionFee:        5.6,
+				SellerTransactionFee: 2.14,
+				SellerVoucherCode:    []string{"SHOP10"},
+			},
+		},
+		{
+			name: "seller discount and shipping",
+			order: OrderDetail{
+				Region:               "TH",
+				TotalAmount:          215,
+				EstimatedShippingFee: 15,
+				ItemList:             []OrderItem{{ModelQuantityPurchased: 2, ModelOriginalPrice: 120, ModelDiscountedPrice: 100}},
+			},
+			want: OrderIncome{
+				EscrowAmount:         185.7,
+				BuyerTotalAmount:     215,
+				OriginalPrice:        240,
+				SellerDiscount:       40,
+				BuyerPaidShippingFee: 15,
+				EstimatedShippingFee: 15,
+				CommissionFee:        10,
+				SellerTransactionFee: 4.3,
+				SellerVoucherCode:    []string{},
+			},
+		},
+		{
+			name: "whole currency units",
+			order: OrderDetail{
+				Region:      "VN",
+				TotalAmount: 119999,
+				ItemList:    []OrderItem{{ModelQuantityPurchased: 1, ModelOriginalPrice: 150000, ModelDiscountedPrice: 119999}},
+			},
+			want: OrderIncome{
+				EscrowAmount:         111599,
+				BuyerTotalAmount:     119999,
+				OriginalPrice:        150000,
+				SellerDiscount:       30001,
+				CommissionFee:        6000,
+				SellerTransactionFee: 2400,
+				SellerVoucherCode:    []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderIncome(tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderIncome() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AddItemRequest struct {
+	ItemName      string          `json:"item_name" example:"seller discount"`
+	Description   string          `json:"description" example:"first product 001first product"`
+	ItemSKU       string          `json:"item_sku" example:"-"`
+	OriginalPrice float64         `json:"original_price" example:"122.02"`
+	CategoryID    int64           `json:"category_id" example:"14646"`
+	AttributeList []Attribute     `json:"attribute_list"`
+	Brand         Brand           `json:"brand"`
+	Image         ItemImage       `json:"image"`
+	VideoUploadID []string        `json:"video_upload_id" example:"th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+	Weight        float64         `json:"weight" example:"10.02"`
+	Dimension     Dimension       `json:"dimension"`
+	Condition     string          `json:"condition" example:"NEW"`
+	ItemStatus    string          `json:"item_status" example:"NORMAL"`
+	SellerStock   []StockLocation `json:"seller_stock"`
+}
+
+type UpdateItemRequest struct {
+	ItemID        int64       `json:"item_id" example:"34001"`
+	ItemName      *string     `json:"item_name,omitempty" example:"seller discount"`
+	Description   *string     `json:"description,omitempty" example:"first product 001first product"`
+	ItemSKU       *string     `json:"item_sku,omitempty" example:"-"`
+	CategoryID    *int64      `json:"category_id,omitempty" example:"14646"`
+	AttributeList []Attribute `json:"attribute_list,omitempty"`
+	Brand         *Brand      `json:"brand,omitempty"`
+	Image         *ItemImage  `json:"image,omitempty"`
+	VideoUploadID []string    `json:"video_upload_id,omitempty" example:"th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+	Weight        *float64    `json:"weight,omitempty" example:"10.02"`
+	Dimension     *Dimension  `json:"dimension,omitempty"`
+	Condition     *string     `json:"condition,omitempty" example:"NEW"`
+	ItemStatus    *string     `json:"item_status,omitempty" example:"NORMAL"`
+}
+
+type ItemWriteResponse struct {
+	Error     string      `json:"error" example:""`
+	Message   string      `json:"message" example:""`
+	Warning   string      `json:"warning" example:""`
+	RequestID string      `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
+	Response  *ItemDetail `json:"response,omitempty"`
+}
+
+// addItem creates a new item
+// @Summary Add item
+// @Description Creates a new item after validating its category, attributes and brand against the catalogue
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body AddItemRequest true "Item to create"
+// @Success 200 {object} ItemWriteResponse "Success response"
+// @Failure 400 {object} ItemWriteResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/product/add_item [post]
+func addItem(c *fiber.Ctx) error {
+	var req AddItemRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ItemName == "" || req.OriginalPrice <= 0 {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "item_name and a positive original_price are required",
+		})
+	}
+
+	shop := currentShop(c)
+	item, err := newItemFromRequest(req, shop.Currency)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(ItemWriteResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	item = store.addItem(shop.ShopID, item)
+	return c.JSON(ItemWriteResponse{
+		RequestID: newRequestID(),
+		Response:  &item,
+	})
+}
+
+// newItemFromRequest builds an item priced in currency from an add request,
+// resolving its media and validating it against the catalogue. The caller
+// assigns the ID when storing it.
+func newItemFromRequest(req AddItemRequest, currency string) (ItemDetail, error) {
+	now := clock.Now().Unix()
+	item := newMockItem(0)
+	item.ItemName = req.ItemName
+	item.Description = req.Description
+	item.ItemSKU = req.ItemSKU
+	item.CategoryID = req.CategoryID
+	item.AttributeList = req.AttributeList
+	item.Brand = req.Brand
+	item.Image = req.Image
+	item.VideoInfo = []VideoInfo{}
+	item.Weight = strconv.FormatFloat(req.Weight, 'f', 2, 64)
+	item.Dimension = req.Dimension
+	item.Condition = req.Condition
+	item.ItemStatus = "NORMAL"
+	if req.ItemStatus != "" {
+		item.ItemStatus = req.ItemStatus
+	}
+	item.CreateTime = now
+	item.UpdateTime = now
+	item.PromotionID = 0
+	item.PriceInfo[0].OriginalPrice = req.OriginalPrice
+	item.PriceInfo[0].CurrentPrice = req.OriginalPrice
+
+	stock := 0
+	for _, location := range req.SellerStock {
+		stock += location.Stock
+	}
+	item.StockInfoV2 = StockInfoV2{
+		SummaryInfo: SummaryInfo{TotalAvailableStock: stock},
+		SellerStock: req.SellerStock,
+		ShopeeStock: []StockLocation{},
+	}
+
+	localizeItem(&item, currency)
+	if err := resolveItemMedia(&item, req.Image.ImageIDList, req.VideoUploadID); err != nil {
+		return ItemDetail{}, err
+	}
+	if err := catalogue.validateItem(&item); err != nil {
+		return ItemDetail{}, err
+	}
+	return item, nil
+}
+
+// updateItem updates an existing item
+// @Summary Update item
+// @Description Updates the given fields of an item and revalidates it against the catalogue
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body UpdateItemRequest true "Fields to update"
+// @Success 200 {object} ItemWriteResponse "Success response"
+// @Failure 400 {object} ItemWriteResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/product/update_item [post]
+func updateItem(c *fiber.Ctx) error {
+	var req UpdateItemRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ItemID == 0 {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "item_id is required",
+		})
+	}
+
+	item, err := store.updateItem(currentShop(c).ShopID, req.ItemID, func(item *ItemDetail) error {
+		applyItemUpdate(item, req)
+		item.UpdateTime = clock.Now().Unix()
+		if req.Image != nil || req.VideoUploadID != nil {
+			if err := resolveItemMedia(item, item.Image.ImageIDList, req.VideoUploadID); err != nil {
+				return err
+			}
+		}
+		return catalogue.validateItem(item)
+	})
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(ItemWriteResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(ItemWriteResponse{
+		RequestID: newRequestID(),
+		Response:  &item,
+	})
+}
+
+// applyItemUpdate copies the fields set in req onto item. The attribute list
+// is always copied because catalogue validation rewrites it in place.
+func applyItemUpdate(item *ItemDetail, req UpdateItemRequest) {
+	if req.ItemName != nil {
+		item.ItemName = *req.ItemName
+	}
+	if req.Description != nil {
+		item.Description = *req.Description
+	}
+	if req.ItemSKU != nil {
+		item.ItemSKU = *req.ItemSKU
+	}
+	if req.CategoryID != nil {
+		item.CategoryID = *req.CategoryID
+	}
+	if req.AttributeList != nil {
+		item.AttributeList = req.AttributeList
+	} else {
+		item.AttributeList = append([]Attribute(nil), item.AttributeList...)
+	}
+	if req.Brand != nil {
+		item.Brand = *req.Brand
+	}
+	if req.Image != nil {
+		item.Image = *req.Image
+	}
+	if req.Weight != nil {
+		item.Weight = strconv.FormatFloat(*req.Weight, 'f', 2, 64)
+	}
+	if req.Dimension != nil {
+		item.Dimension = *req.Dimension
+	}
+	if req.Condition != nil {
+		item.Condition = *req.Condition
+	}
+	if req.ItemStatus != nil {
+		item.ItemStatus = *req.ItemStatus
+	}
+}
+
+// resolveItemMedia points an item's images and videos at the uploaded media
+// space assets. Image URLs are always derived from the image IDs; videos are
+// only replaced when video upload IDs are given.
+func resolveItemMedia(item *ItemDetail, imageIDs, videoUploadIDs []string) error {
+	urls, err := media.imageURLs(imageIDs)
+	if err != nil {
+		return err
+	}
+	item.Image = ItemImage{
+		ImageURLList: urls,
+		ImageIDList:  append([]string{}, imageIDs...),
+	}
+
+	if videoUploadIDs == nil {
+		return nil
+	}
+	videos := make([]VideoInfo, 0, len(videoUploadIDs))
+	for _, videoUploadID := range videoUploadIDs {
+		video, err := media.videoInfo(videoUploadID)
+		if err != nil {
+			return err
+		}
+		videos = append(videos, video)
+	}
+	item.VideoInfo = videos
+	return nil
+}
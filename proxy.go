@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	proxyOff    = "off"
+	proxyRecord = "record"
+	proxyReplay = "replay"
+)
+
+// proxyAuthParams are the query parameters the proxy signs itself. They are
+// left out of fixture keys so recordings replay for any caller.
+var proxyAuthParams = map[string]bool{
+	"partner_id":   true,
+	"timestamp":    true,
+	"access_token": true,
+	"sign":         true,
+}
+
+// ProxyConfig controls the record and replay proxy. In record mode API
+// requests are forwarded to UpstreamURL, re-signed with the partner
+// credentials, and each answer is saved under FixtureDir. In replay mode
+// recorded answers are served in place of the mock's own, and requests
+// without a recording fall through to the mock.
+type ProxyConfig struct {
+	Mode        string `json:"mode" example:"record"`
+	UpstreamURL string `json:"upstream_url" example:"https://partner.test-stable.shopeemobile.com"`
+	PartnerID   int64  `json:"partner_id" example:"123456"`
+	PartnerKey  string `json:"partner_key,omitempty" example:"your_partner_key_here"`
+	AccessToken string `json:"access_token" example:"your_access_token"`
+	ShopID      int64  `json:"shop_id" example:"789012"`
+	FixtureDir  string `json:"fixture_dir" example:"fixtures"`
+}
+
+// Fixture is one recorded request and the upstream's answer to it.
+type Fixture struct {
+	Key          string          `json:"key" example:"3f0c1a9b5e7d2c48"`
+	Method       string          `json:"method" example:"GET"`
+	Path         string          `json:"path" example:"/api/v2/order/get_order_detail"`
+	Query        string          `json:"query" example:"order_sn_list=2404098R48U37H&shop_id=789012"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty" swaggertype:"object"`
+	Status       int             `json:"status" example:"200"`
+	ContentType  string          `json:"content_type" example:"application/json"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty" swaggertype:"object"`
+	ResponseText string          `json:"response_text,omitempty" example:""`
+	RecordedAt   int64           `json:"recorded_at" example:"1758274838"`
+}
+
+type FixtureListResponse struct {
+	FixtureList []Fixture `json:"fixture_list"`
+}
+
+type proxyRecorder struct {
+	mu       sync.Mutex
+	config   ProxyConfig
+	client   *http.Client
+	fixtures map[string]Fixture
+}
+
+var proxy = &proxyRecorder{
+	config: ProxyConfig{
+		Mode:        getEnv("PROXY_MODE", proxyOff),
+		UpstreamURL: getEnv("PROXY_UPSTREAM_URL", "https://partner.test-stable.shopeemobile.com"),
+		PartnerID:   int64(getEnvInt("PROXY_PARTNER_ID", 0)),
+		PartnerKey:  getEnv("PROXY_PARTNER_KEY", ""),
+		AccessToken: getEnv("PROXY_ACCESS_TOKEN", ""),
+		ShopID:      int64(getEnvInt("PROXY_SHOP_ID", 0)),
+		FixtureDir:  getEnv("FIXTURE_DIR", "fixtures"),
+	},
+	client:   &http.Client{Timeout: 30 * time.Second},
+	fixtures: make(map[string]Fixture),
+}
+
+func (c ProxyConfig) validate() error {
+	switch c.Mode {
+	case proxyOff, proxyReplay:
+	case proxyRecord:
+		if c.UpstreamURL == "" || c.PartnerID == 0 || c.PartnerKey == "" {
+			return errors.New("record mode requires upstream_url, partner_id and partner_key")
+		}
+	default:
+		return errors.New("mode must be off, record or replay")
+	}
+	if c.FixtureDir == "" {
+		return errors.New("fixture_dir is required")
+	}
+	return nil
+}
+
+func (p *proxyRecorder) getConfig() ProxyConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.config
+}
+
+// setConfig switches the proxy to config and loads the fixtures recorded
+// under its fixture directory.
+func (p *proxyRecorder) setConfig(config ProxyConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	fixtures, err := loadFixtures(config.FixtureDir)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.fixtures = fixtures
+	return nil
+}
+
+func (p *proxyRecorder) lookup(key string) (Fixture, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fixture, ok := p.fixtures[key]
+	return fixture, ok
+}
+
+func (p *proxyRecorder) list() []Fixture {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	list := make([]Fixture, 0, len(p.fixtures))
+	for _, fixture := range p.fixtures {
+		list = append(list, fixture)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Path != list[j].Path {
+			return list[i].Path < list[j].Path
+		}
+		return list[i].RecordedAt < list[j].RecordedAt
+	})
+	return list
+}
+
+// save writes a fixture to <fixture_dir>/<path>/<key>.json, replacing any
+// earlier recording of the same request.
+func (p *proxyRecorder) save(dir string, fixture Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(dir, strings.Trim(fixture.Path, "/"), fixture.Key+".json")
+	if err := writeFile(name, data); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fixtures[fixture.Key] = fixture
+	return nil
+}
+
+// loadFixtures reads every fixture file under dir. A missing directory
+// holds no fixtures.
+func loadFixtures(dir string) (map[string]Fixture, error) {
+	fixtures := make(map[string]Fixture)
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return fmt.Errorf("parse fixture %s: %w", path, err)
+		}
+		fixtures[fixture.Key] = fixture
+		return nil
+	})
+	return fixtures, err
+}
+
+// fixtureQuery returns the request's query without the auth parameters,
+// sorted so equal requests give equal keys.
+func fixtureQuery(c *fiber.Ctx) string {
+	values := url.Values{}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if !proxyAuthParams[string(key)] {
+			values.Add(string(key), string(value))
+		}
+	})
+	return values.Encode()
+}
+
+func fixtureKey(method, path, query string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s?%s\n", method, path, query)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// proxySign signs an upstream request the way the Shopee Open Platform
+// expects: lower-case hex HMAC-SHA256, keyed with the partner key, over
+// partner_id, path and timestamp, followed for shop calls by access_token
+// and shop_id and for merchant calls by access_token and merchant_id.
+func proxySign(partnerKey, path string, values url.Values) string {
+	base := values.Get("partner_id") + path + values.Get("timestamp")
+	switch {
+	case publicAPI(path):
+	case merchantAPI(path):
+		base += values.Get("access_token") + values.Get("merchant_id")
+	default:
+		base += values.Get("access_token") + values.Get("shop_id")
+	}
+	h := hmac.New(sha256.New, []byte(partnerKey))
+	h.Write([]byte(base))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// forward sends a request on to the upstream with the proxy's credentials
+// and records the answer.
+func (p *proxyRecorder) forward(c *fiber.Ctx, config ProxyConfig, query string, body []byte) (Fixture, error) {
+	// The upstream checks timestamps against real time, so the virtual
+	// clock is deliberately not used here.
+	timestamp := time.Now().Unix()
+
+	values, _ := url.ParseQuery(query)
+	values.Set("partner_id", strconv.FormatInt(config.PartnerID, 10))
+	values.Set("timestamp", strconv.FormatInt(timestamp, 10))
+	if config.AccessToken != "" {
+		values.Set("access_token", config.AccessToken)
+	}
+	if config.ShopID != 0 && !merchantAPI(c.Path()) {
+		values.Set("shop_id", strconv.FormatInt(config.ShopID, 10))
+	}
+	values.Set("sign", proxySign(config.PartnerKey, c.Path(), values))
+
+	target := strings.TrimRight(config.UpstreamURL, "/") + c.Path() + "?" + values.Encode()
+	req, err := http.NewRequest(c.Method(), target, bytes.NewReader(body))
+	if err != nil {
+		return Fixture{}, err
+	}
+	if contentType := c.Get(fiber.HeaderContentType); contentType != "" {
+		req.Header.Set(fiber.HeaderContentType, contentType)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Fixture{}, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	fixture := Fixture{
+		Key:         fixtureKey(c.Method(), c.Path(), query, body),
+		Method:      strings.Clone(c.Method()),
+		Path:        strings.Clone(c.Path()),
+		Query:       query,
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get(fiber.HeaderContentType),
+		RecordedAt:  clock.Now().Unix(),
+	}
+	if json.Valid(body) {
+		// body is Fiber's request buffer, reused once the request ends
+		fixture.RequestBody = append(json.RawMessage(nil), body...)
+	}
+	if json.Valid(respBody) {
+		fixture.ResponseBody = respBody
+	} else {
+		fixture.ResponseText = string(respBody)
+	}
+
+	if err := p.save(config.FixtureDir, fixture); err != nil {
+		log.Printf("proxy: save fixture for %s: %v", c.Path(), err)
+	}
+	return fixture, nil
+}
+
+func sendFixture(c *fiber.Ctx, fixture Fixture) error {
+	if fixture.ContentType != "" {
+		c.Set(fiber.HeaderContentType, fixture.ContentType)
+	}
+	c.Status(fixture.Status)
+	if fixture.ResponseBody != nil {
+		// Fixture files are indented for reading; answer with the compact
+		// form the upstream sent.
+		var body bytes.Buffer
+		if err := json.Compact(&body, fixture.ResponseBody); err != nil {
+			return err
+		}
+		return c.Send(body.Bytes())
+	}
+	return c.SendString(fixture.ResponseText)
+}
+
+// proxyMiddleware is a Fiber middleware that forwards and records API
+// requests in record mode and serves recordings in replay mode.
+func proxyMiddleware(c *fiber.Ctx) error {
+	config := proxy.getConfig()
+	if config.Mode == proxyOff {
+		return c.Next()
+	}
+
+	query := fixtureQuery(c)
+	body := c.Body()
+
+	if config.Mode == proxyReplay {
+		fixture, ok := proxy.lookup(fixtureKey(c.Method(), c.Path(), query, body))
+		if !ok {
+			return c.Next()
+		}
+		return sendFixture(c, fixture)
+	}
+
+	fixture, err := proxy.forward(c, config, query, body)
+	if err != nil {
+		return c.Status(502).JSON(fiber.Map{
+			"request_id": newRequestID(),
+			"error":      "error_proxy",
+			"message":    err.Error(),
+		})
+	}
+	return sendFixture(c, fixture)
+}
+
+// adminGetProxy returns the record and replay proxy settings
+// @Summary Get proxy config
+// @Description Returns the proxy mode, upstream and credentials. The partner key is never returned.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ProxyConfig "Current settings"
+// @Router /admin/proxy [get]
+func adminGetProxy(c *fiber.Ctx) error {
+	config := proxy.getConfig()
+	config.PartnerKey = ""
+	return c.JSON(config)
+}
+
+// adminSetProxy changes the record and replay proxy settings
+// @Summary Set proxy config
+// @Description Switches between off, record and replay mode and reloads the fixtures under fixture_dir. An empty partner_key keeps the current one.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body ProxyConfig true "New settings"
+// @Success 200 {object} ProxyConfig "Updated settings"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/proxy [put]
+func adminSetProxy(c *fiber.Ctx) error {
+	var req ProxyConfig
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.PartnerKey == "" {
+		req.PartnerKey = proxy.getConfig().PartnerKey
+	}
+	if req.FixtureDir == "" {
+		req.FixtureDir = proxy.getConfig().FixtureDir
+	}
+	if err := proxy.setConfig(req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": err.Error(),
+		})
+	}
+
+	req.PartnerKey = ""
+	return c.JSON(req)
+}
+
+// adminListFixtures lists the recorded fixtures
+// @Summary List fixtures
+// @Description Lists the request and response pairs recorded by the proxy, grouped by path
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} FixtureListResponse "Fixtures"
+// @Router /admin/proxy/fixtures [get]
+func adminListFixtures(c *fiber.Ctx) error {
+	return c.JSON(FixtureListResponse{FixtureList: proxy.list()})
+}
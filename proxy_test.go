@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestProxySign(t *testing.T) {
+	// Both IDs are set so each form has to pick the right one
+	values := url.Values{
+		"partner_id":   {"1000"},
+		"timestamp":    {"1700000000"},
+		"access_token": {"tok"},
+		"shop_id":      {"789012"},
+		"merchant_id":  {"1001"},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			// 1000/api/v2/shop/get_shop_info1700000000tok789012
+			name: "shop",
+			path: "/api/v2/shop/get_shop_info",
+			want: "1b72786d871ff59c5f75038464aad82e52315b0b1ce709df30851b581be0dc3f",
+		},
+		{
+			// 1000/api/v2/merchant/get_shop_list_by_merchant1700000000tok1001
+			name: "merchant",
+			path: "/api/v2/merchant/get_shop_list_by_merchant",
+			want: "c819b74eea61b5f862dd5fca2908642c38a5ec7baeb14b0b6690d6b1aaf7d06e",
+		},
+		{
+			// 1000/api/v2/global_product/get_global_item_info1700000000tok1001
+			name: "global product",
+			path: "/api/v2/global_product/get_global_item_info",
+			want: "8e819c3c73ba12fbf96dbbdff703372932203ef998580e4bf512098cafd91aec",
+		},
+		{
+			// 1000/api/v2/auth/token/get1700000000
+			name: "public",
+			path: "/api/v2/auth/token/get",
+			want: "9da745a93f1551b2ce9279041d7efbd45cdf69f8ead91279949b38bcad0c04df",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := proxySign("partner_key", tt.path, values); got != tt.want {
+			t.Errorf("%s: proxySign() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	rateScopePartner = "partner"
+	rateScopeShop    = "shop"
+	rateScopePath    = "path"
+)
+
+var errRateLimitNotFound = errors.New("rate limit not found")
+
+// RateLimit caps the requests matching its partner, shop and path filters.
+// Zero filters match everything and Path may end in "*" to match a prefix.
+// Scope sets what is counted together: each partner, each partner and shop,
+// or each partner, shop and path (the default).
+type RateLimit struct {
+	ID         int64   `json:"id" example:"1"`
+	PartnerID  int64   `json:"partner_id" example:"123456"`
+	ShopID     int64   `json:"shop_id" example:"789012"`
+	Path       string  `json:"path" example:"/api/v2/order/*"`
+	Scope      string  `json:"scope" example:"path"`
+	QPS        float64 `json:"qps" example:"10"`
+	DailyQuota int     `json:"daily_quota" example:"100000"`
+}
+
+type RateLimitListResponse struct {
+	RateLimitList []RateLimit `json:"rate_limit_list"`
+}
+
+func (r RateLimit) matches(partnerID, shopID int64, path string) bool {
+	if r.PartnerID != 0 && r.PartnerID != partnerID {
+		return false
+	}
+	if r.ShopID != 0 && r.ShopID != shopID {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return r.Path == "" || r.Path == path
+}
+
+func (r RateLimit) validate() error {
+	switch r.Scope {
+	case "", rateScopePartner, rateScopeShop, rateScopePath:
+	default:
+		return errors.New("scope must be partner, shop or path")
+	}
+	if r.QPS < 0 || r.DailyQuota < 0 {
+		return errors.New("qps and daily_quota must not be negative")
+	}
+	if r.QPS == 0 && r.DailyQuota == 0 {
+		return errors.New("qps or daily_quota is required")
+	}
+	return nil
+}
+
+func (r RateLimit) bucketKey(partnerID, shopID int64, path string) string {
+	switch r.Scope {
+	case rateScopePartner:
+		return fmt.Sprintf("%d|%d", r.ID, partnerID)
+	case rateScopeShop:
+		return fmt.Sprintf("%d|%d|%d", r.ID, partnerID, shopID)
+	default:
+		return fmt.Sprintf("%d|%d|%d|%s", r.ID, partnerID, shopID, path)
+	}
+}
+
+// rateBucket is a token bucket refilled at the limit's QPS, plus a counter
+// for the current day's quota.
+type rateBucket struct {
+	tokens   float64
+	refilled time.Time
+	day      string
+	used     int
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	limits  []RateLimit
+	buckets map[string]*rateBucket
+	nextID  int64
+}
+
+var rateLimits = &rateLimiter{
+	buckets: make(map[string]*rateBucket),
+	nextID:  1,
+}
+
+func (l *rateLimiter) list() []RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]RateLimit{}, l.limits...)
+}
+
+func (l *rateLimiter) add(limit RateLimit) RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit.ID = l.nextID
+	l.nextID++
+	l.limits = append(l.limits, limit)
+	return limit
+}
+
+func (l *rateLimiter) replace(id int64, limit RateLimit) (RateLimit, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.limits {
+		if l.limits[i].ID == id {
+			limit.ID = id
+			l.limits[i] = limit
+			l.resetBuckets(id)
+			return limit, nil
+		}
+	}
+	return RateLimit{}, errRateLimitNotFound
+}
+
+func (l *rateLimiter) remove(id int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.limits {
+		if l.limits[i].ID == id {
+			l.limits = append(l.limits[:i], l.limits[i+1:]...)
+			l.resetBuckets(id)
+			return nil
+		}
+	}
+	return errRateLimitNotFound
+}
+
+func (l *rateLimiter) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = nil
+	l.buckets = make(map[string]*rateBucket)
+}
+
+// resetBuckets drops the counters of one limit. Callers must hold l.mu.
+func (l *rateLimiter) resetBuckets(id int64) {
+	prefix := fmt.Sprintf("%d|", id)
+	for key := range l.buckets {
+		if strings.HasPrefix(key, prefix) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// allow charges one request against every matching limit. The request is
+// refused, and nothing is charged, if any limit is exhausted. QPS buckets
+// refill on wall time, so freezing or rewinding the virtual clock cannot
+// starve them; daily quotas reset on the virtual clock's day.
+func (l *rateLimiter) allow(partnerID, shopID int64, path string, wall, virtual time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	day := virtual.UTC().Format("2006-01-02")
+	var charged []*rateBucket
+	for _, limit := range l.limits {
+		if !limit.matches(partnerID, shopID, path) {
+			continue
+		}
+
+		// Burst capacity is one second's worth of requests, but at least one
+		burst := math.Max(limit.QPS, 1)
+		key := limit.bucketKey(partnerID, shopID, path)
+		bucket, ok := l.buckets[key]
+		if !ok {
+			bucket = &rateBucket{tokens: burst, refilled: wall, day: day}
+			l.buckets[key] = bucket
+		}
+
+		if limit.QPS > 0 {
+			if elapsed := wall.Sub(bucket.refilled); elapsed > 0 {
+				bucket.tokens = math.Min(bucket.tokens+elapsed.Seconds()*limit.QPS, burst)
+				bucket.refilled = wall
+			}
+			if bucket.tokens < 1 {
+				return false
+			}
+		}
+		if bucket.day != day {
+			bucket.day = day
+			bucket.used = 0
+		}
+		if limit.DailyQuota > 0 && bucket.used >= limit.DailyQuota {
+			return false
+		}
+		charged = append(charged, bucket)
+	}
+
+	for _, bucket := range charged {
+		bucket.tokens--
+		bucket.used++
+	}
+	return true
+}
+
+// rateLimit is a Fiber middleware that refuses API requests over the
+// configured limits with Shopee's error_rate_limit response.
+func rateLimit(c *fiber.Ctx) error {
+	partnerID, _ := strconv.ParseInt(c.Query("partner_id"), 10, 64)
+
+	if !rateLimits.allow(partnerID, requestShopID(c), c.Path(), time.Now(), clock.Now()) {
+		return c.Status(429).JSON(fiber.Map{
+			"request_id": newRequestID(),
+			"error":      "error_rate_limit",
+			"message":    "The request has exceeded the rate limit, please try again later.",
+		})
+	}
+	return c.Next()
+}
+
+func rateLimitError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errRateLimitNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminListRateLimits lists the rate limits
+// @Summary List rate limits
+// @Description Lists the QPS and daily quota limits applied to API requests
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} RateLimitListResponse "Limits"
+// @Router /admin/rate_limits [get]
+func adminListRateLimits(c *fiber.Ctx) error {
+	return c.JSON(RateLimitListResponse{RateLimitList: rateLimits.list()})
+}
+
+// adminAddRateLimit adds a rate limit
+// @Summary Add rate limit
+// @Description Adds a QPS and/or daily quota limit for matching partner, shop and path
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body RateLimit true "Limit to add"
+// @Success 200 {object} RateLimit "Added limit"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Router /admin/rate_limits [post]
+func adminAddRateLimit(c *fiber.Ctx) error {
+	var limit RateLimit
+
+	if err := c.BodyParser(&limit); err != nil {
+		return rateLimitError(c, errors.New("Invalid request body"))
+	}
+	if err := limit.validate(); err != nil {
+		return rateLimitError(c, err)
+	}
+
+	return c.JSON(rateLimits.add(limit))
+}
+
+// adminUpdateRateLimit replaces a rate limit
+// @Summary Update rate limit
+// @Description Replaces an existing rate limit and resets its counters
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Limit ID" example(1)
+// @Param request body RateLimit true "New limit"
+// @Success 200 {object} RateLimit "Updated limit"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Failure 404 {object} map[string]interface{} "Unknown limit"
+// @Router /admin/rate_limits/{id} [put]
+func adminUpdateRateLimit(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return rateLimitError(c, errRateLimitNotFound)
+	}
+
+	var limit RateLimit
+	if err := c.BodyParser(&limit); err != nil {
+		return rateLimitError(c, errors.New("Invalid request body"))
+	}
+	if err := limit.validate(); err != nil {
+		return rateLimitError(c, err)
+	}
+
+	limit, err = rateLimits.replace(id, limit)
+	if err != nil {
+		return rateLimitError(c, err)
+	}
+	return c.JSON(limit)
+}
+
+// adminDeleteRateLimit removes a rate limit
+// @Summary Delete rate limit
+// @Description Removes one rate limit and its counters
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Limit ID" example(1)
+// @Success 204 "Limit removed"
+// @Failure 404 {object} map[string]interface{} "Unknown limit"
+// @Router /admin/rate_limits/{id} [delete]
+func adminDeleteRateLimit(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return rateLimitError(c, errRateLimitNotFound)
+	}
+
+	if err := rateLimits.remove(id); err != nil {
+		return rateLimitError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// adminClearRateLimits removes every rate limit
+// @Summary Clear rate limits
+// @Description Removes every rate limit and counter
+// @Tags Admin
+// @Success 204 "Limits removed"
+// @Router /admin/rate_limits [delete]
+func adminClearRateLimits(c *fiber.Ctx) error {
+	rateLimits.clear()
+	return c.SendStatus(204)
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegionProfile describes how one Shopee market formats its data: the
+// currency and its precision, the address and phone formats, and the
+// payment methods and carriers buyers see. THBRate converts the canned
+// fixtures, which are priced in baht, into the local currency.
+type RegionProfile struct {
+	Region         string           `json:"region" example:"TH"`
+	Country        string           `json:"country" example:"Thailand"`
+	Currency       string           `json:"currency" example:"THB"`
+	PriceDecimals  int              `json:"price_decimals" example:"2"`
+	THBRate        float64          `json:"thb_rate" example:"1"`
+	PhoneCode      string           `json:"phone_code" example:"66"`
+	TrackingPrefix string           `json:"tracking_prefix" example:"TH"`
+	PaymentMethods []string         `json:"payment_methods" example:"Cash on Delivery,Credit Card/Debit Card"`
+	Carriers       []string         `json:"carriers" example:"Kerry Express,Flash Express"`
+	BuyerName      string           `json:"buyer_name" example:"กลวัชร หัสไทรทอง"`
+	BuyerPhone     string           `json:"buyer_phone" example:"0909573314"`
+	Address        AddressBreakdown `json:"address"`
+}
+
+type RegionProfileListResponse struct {
+	RegionList []RegionProfile `json:"region_list"`
+}
+
+var regionProfiles = map[string]RegionProfile{
+	"TH": {
+		Region:         "TH",
+		Country:        "Thailand",
+		Currency:       "THB",
+		PriceDecimals:  2,
+		THBRate:        1,
+		PhoneCode:      "66",
+		TrackingPrefix: "TH",
+		PaymentMethods: []string{"Cash on Delivery", "Credit Card/Debit Card", "ShopeePay", "Mobile Banking"},
+		Carriers:       []string{"Kerry Express", "Flash Express", "Thailand Post", "SPX Express", "J&T Express"},
+		BuyerName:      "กลวัชร หัสไทรทอง",
+		BuyerPhone:     "0909573314",
+		Address: AddressBreakdown{
+			State:           "จังหวัดกรุงเทพมหานคร",
+			City:            "เขตห้วยขวาง",
+			District:        "แขวงบางกะปิ",
+			Postcode:        "10310",
+			DetailedAddress: "99/9 ถนนพระราม 9",
+		},
+	},
+	"VN": {
+		Region:         "VN",
+		Country:        "Vietnam",
+		Currency:       "VND",
+		PriceDecimals:  0,
+		THBRate:        700,
+		PhoneCode:      "84",
+		TrackingPrefix: "VN",
+		PaymentMethods: []string{"Cash on Delivery", "ShopeePay", "Credit Card/Debit Card"},
+		Carriers:       []string{"Giao Hàng Nhanh", "Giao Hàng Tiết Kiệm", "SPX Express", "J&T Express", "Ninja Van"},
+		BuyerName:      "Nguyễn Văn An",
+		BuyerPhone:     "0912345678",
+		Address: AddressBreakdown{
+			State:           "TP. Hồ Chí Minh",
+			City:            "Quận 1",
+			District:        "Phường Bến Nghé",
+			Postcode:        "700000",
+			DetailedAddress: "12 Lê Lợi",
+		},
+	},
+	"SG": {
+		Region:         "SG",
+		Country:        "Singapore",
+		Currency:       "SGD",
+		PriceDecimals:  2,
+		THBRate:        0.037,
+		PhoneCode:      "65",
+		TrackingPrefix: "SPXSG",
+		PaymentMethods: []string{"Credit Card/Debit Card", "ShopeePay", "PayNow"},
+		Carriers:       []string{"SPX Express", "Ninja Van", "J&T Express", "Qxpress"},
+		BuyerName:      "Tan Wei Ming",
+		BuyerPhone:     "91234567",
+		Address: AddressBreakdown{
+			Postcode:        "018956",
+			DetailedAddress: "10 Bayfront Avenue #12-01",
+		},
+	},
+	"MY": {
+		Region:         "MY",
+		Country:        "Malaysia",
+		Currency:       "MYR",
+		PriceDecimals:  2,
+		THBRate:        0.13,
+		PhoneCode:      "60",
+		TrackingPrefix: "MY",
+		PaymentMethods: []string{"Cash on Delivery", "Online Banking", "ShopeePay", "Credit Card/Debit Card"},
+		Carriers:       []string{"Pos Laju", "J&T Express", "Ninja Van", "SPX Express", "City-Link Express"},
+		BuyerName:      "Ahmad bin Ismail",
+		BuyerPhone:     "0123456789",
+		Address: AddressBreakdown{
+			State:           "Selangor",
+			City:            "Petaling Jaya",
+			Postcode:        "47800",
+			DetailedAddress: "No. 8 Jalan PJU 7/3",
+		},
+	},
+	"PH": {
+		Region:         "PH",
+		Country:        "Philippines",
+		Currency:       "PHP",
+		PriceDecimals:  2,
+		THBRate:        1.6,
+		PhoneCode:      "63",
+		TrackingPrefix: "PH",
+		PaymentMethods: []string{"Cash on Delivery", "GCash", "ShopeePay", "Credit Card/Debit Card"},
+		Carriers:       []string{"SPX Express", "J&T Express", "Flash Express", "Ninja Van", "2GO Express"},
+		BuyerName:      "Juan Dela Cruz",
+		BuyerPhone:     "09171234567",
+		Address: AddressBreakdown{
+			State:           "Metro Manila",
+			City:            "Makati City",
+			District:        "San Lorenzo",
+			Postcode:        "1223",
+			DetailedAddress: "123 Ayala Avenue",
+		},
+	},
+	"ID": {
+		Region:         "ID",
+		Country:        "Indonesia",
+		Currency:       "IDR",
+		PriceDecimals:  0,
+		THBRate:        450,
+		PhoneCode:      "62",
+		TrackingPrefix: "ID",
+		PaymentMethods: []string{"Cash on Delivery", "ShopeePay", "Transfer Bank", "Indomaret"},
+		Carriers:       []string{"J&T Express", "JNE Reguler", "SiCepat REG", "SPX Standard", "AnterAja"},
+		BuyerName:      "Budi Santoso",
+		BuyerPhone:     "081234567890",
+		Address: AddressBreakdown{
+			State:           "DKI Jakarta",
+			City:            "Kota Jakarta Selatan",
+			District:        "Kebayoran Baru",
+			Town:            "Senayan",
+			Postcode:        "12190",
+			DetailedAddress: "Jl. Jenderal Sudirman No. 52",
+		},
+	},
+	"TW": {
+		Region:         "TW",
+		Country:        "Taiwan",
+		Currency:       "TWD",
+		PriceDecimals:  0,
+		THBRate:        0.9,
+		PhoneCode:      "886",
+		TrackingPrefix: "TW",
+		PaymentMethods: []string{"Cash on Delivery", "Credit Card/Debit Card", "ShopeePay"},
+		Carriers:       []string{"7-ELEVEN", "全家", "萊爾富", "黑貓宅急便", "蝦皮店到店"},
+		BuyerName:      "陳小明",
+		BuyerPhone:     "0912345678",
+		Address: AddressBreakdown{
+			State:           "臺北市",
+			City:            "信義區",
+			Postcode:        "110",
+			DetailedAddress: "市府路45號",
+		},
+	},
+	"BR": {
+		Region:         "BR",
+		Country:        "Brazil",
+		Currency:       "BRL",
+		PriceDecimals:  2,
+		THBRate:        0.15,
+		PhoneCode:      "55",
+		TrackingPrefix: "BR",
+		PaymentMethods: []string{"Pix", "Credit Card", "Boleto Bancário"},
+		Carriers:       []string{"Shopee Xpress", "Correios", "Loggi", "Total Express", "Jadlog"},
+		BuyerName:      "João da Silva",
+		BuyerPhone:     "11987654321",
+		Address: AddressBreakdown{
+			State:           "SP",
+			City:            "São Paulo",
+			District:        "Bela Vista",
+			Postcode:        "01310-100",
+			DetailedAddress: "Avenida Paulista, 1578",
+		},
+	},
+	"MX": {
+		Region:         "MX",
+		Country:        "Mexico",
+		Currency:       "MXN",
+		PriceDecimals:  2,
+		THBRate:        0.5,
+		PhoneCode:      "52",
+		TrackingPrefix: "MX",
+		PaymentMethods: []string{"Credit Card/Debit Card", "OXXO", "SPEI"},
+		Carriers:       []string{"Estafeta", "DHL Express", "FedEx", "J&T Express", "iMile"},
+		BuyerName:      "María García López",
+		BuyerPhone:     "5512345678",
+		Address: AddressBreakdown{
+			State:           "Ciudad de México",
+			City:            "Cuauhtémoc",
+			District:        "Juárez",
+			Postcode:        "06600",
+			DetailedAddress: "Paseo de la Reforma 222",
+		},
+	},
+	"PL": {
+		Region:         "PL",
+		Country:        "Poland",
+		Currency:       "PLN",
+		PriceDecimals:  2,
+		THBRate:        0.11,
+		PhoneCode:      "48",
+		TrackingPrefix: "PL",
+		PaymentMethods: []string{"BLIK", "Przelewy24", "Credit Card/Debit Card", "Cash on Delivery"},
+		Carriers:       []string{"InPost Paczkomaty", "DPD", "Poczta Polska", "Orlen Paczka"},
+		BuyerName:      "Jan Kowalski",
+		BuyerPhone:     "501234567",
+		Address: AddressBreakdown{
+			State:           "mazowieckie",
+			City:            "Warszawa",
+			Town:            "Warszawa",
+			Postcode:        "00-124",
+			DetailedAddress: "ul. Ordona 7B",
+		},
+	},
+}
+
+// regionProfile returns the profile of a region, falling back to Thailand
+// for regions without one.
+func regionProfile(region string) RegionProfile {
+	if profile, ok := regionProfiles[strings.ToUpper(region)]; ok {
+		return profile
+	}
+	return regionProfiles["TH"]
+}
+
+// currencyDecimals returns how many decimals prices in a currency carry.
+func currencyDecimals(currency string) int {
+	for _, profile := range regionProfiles {
+		if profile.Currency == currency {
+			return profile.PriceDecimals
+		}
+	}
+	return 2
+}
+
+func roundPrice(price float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(price*scale) / scale
+}
+
+// price rounds an amount to the region's currency precision.
+func (p RegionProfile) price(amount float64) float64 {
+	return roundPrice(amount, p.PriceDecimals)
+}
+
+// fromTHB converts a canned baht amount into the region's currency.
+func (p RegionProfile) fromTHB(amount float64) float64 {
+	return p.price(amount * p.THBRate)
+}
+
+// addressBreakdown returns the profile's sample address with the country
+// and full address filled in.
+func (p RegionProfile) addressBreakdown() AddressBreakdown {
+	address := p.Address
+	address.Region = p.Country
+	address.FullAddress = joinAddress(address.DetailedAddress, address.Town, address.District, address.City, address.State, address.Postcode)
+	return address
+}
+
+// recipientAddress returns the profile's sample buyer and address as the
+// masked shipping address Shopee shows sellers.
+func (p RegionProfile) recipientAddress() RecipientAddress {
+	return p.maskedAddress(p.Address, p.BuyerName, p.BuyerPhone)
+}
+
+// maskedAddress masks a buyer's name, phone and street address the way
+// Shopee does before showing them to sellers.
+func (p RegionProfile) maskedAddress(address AddressBreakdown, name, phone string) RecipientAddress {
+	return RecipientAddress{
+		City:        address.City,
+		District:    address.District,
+		FullAddress: joinAddress("******", address.Town, address.District, address.City, address.State, address.Postcode),
+		Name:        maskName(name),
+		Phone:       maskPhone(p.PhoneCode, phone),
+		Region:      p.Region,
+		State:       address.State,
+		Town:        address.Town,
+		Zipcode:     address.Postcode,
+	}
+}
+
+// trackingNumber returns a new tracking number in the region's format.
+func (p RegionProfile) trackingNumber() string {
+	return p.TrackingPrefix + randomDigits(12)
+}
+
+func joinAddress(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+// maskName keeps the first and last characters of a name, as Shopee does
+// for recipient names.
+func maskName(name string) string {
+	runes := []rune(name)
+	if len(runes) <= 2 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[0]) + "******" + string(runes[len(runes)-1])
+}
+
+// maskPhone writes a local phone number in international form with all but
+// the last two digits masked.
+func maskPhone(phoneCode, phone string) string {
+	local := strings.TrimPrefix(phone, "0")
+	if len(local) <= 2 {
+		return phoneCode + local
+	}
+	return phoneCode + strings.Repeat("*", len(local)-2) + local[len(local)-2:]
+}
+
+// localizeOrder makes an order use a shop's region: its currency and price
+// precision, a local recipient address, and the market's payment method and
+// carrier.
+func localizeOrder(order *OrderDetail, shop Shop) {
+	profile := regionProfile(shop.Region)
+
+	order.Region = profile.Region
+	order.Currency = shop.Currency
+	order.RecipientAddress = profile.recipientAddress()
+	order.PaymentMethod = profile.PaymentMethods[0]
+	order.COD = order.PaymentMethod == "Cash on Delivery"
+	order.ShippingCarrier = profile.Carriers[0]
+	for i := range order.PackageList {
+		order.PackageList[i].ShippingCarrier = profile.Carriers[0]
+	}
+
+	order.TotalAmount = profile.price(order.TotalAmount)
+	order.EstimatedShippingFee = profile.price(order.EstimatedShippingFee)
+	order.ReverseShippingFee = profile.price(order.ReverseShippingFee)
+	for i := range order.ItemList {
+		order.ItemList[i].ModelOriginalPrice = profile.price(order.ItemList[i].ModelOriginalPrice)
+		order.ItemList[i].ModelDiscountedPrice = profile.price(order.ItemList[i].ModelDiscountedPrice)
+	}
+}
+
+// newRegionalMockOrder returns the canned order as a shop in its region
+// would see it, with its baht amounts converted to the local currency.
+func newRegionalMockOrder(orderSN string, shop Shop) OrderDetail {
+	profile := regionProfile(shop.Region)
+	order := newMockOrder(orderSN)
+
+	order.TotalAmount = profile.fromTHB(order.TotalAmount)
+	order.EstimatedShippingFee = profile.fromTHB(order.EstimatedShippingFee)
+	for i := range order.ItemList {
+		order.ItemList[i].ModelOriginalPrice = profile.fromTHB(order.ItemList[i].ModelOriginalPrice)
+		order.ItemList[i].ModelDiscountedPrice = profile.fromTHB(order.ItemList[i].ModelDiscountedPrice)
+	}
+	localizeOrder(&order, shop)
+	return order
+}
+
+// localizeItem makes an item's prices use a currency and its precision.
+func localizeItem(item *ItemDetail, currency string) {
+	decimals := currencyDecimals(currency)
+	for i := range item.PriceInfo {
+		price := &item.PriceInfo[i]
+		price.Currency = currency
+		price.OriginalPrice = roundPrice(price.OriginalPrice, decimals)
+		price.CurrentPrice = roundPrice(price.CurrentPrice, decimals)
+		price.InflatedPriceOfOriginalPrice = roundPrice(price.InflatedPriceOfOriginalPrice, decimals)
+		price.InflatedPriceOfCurrentPrice = roundPrice(price.InflatedPriceOfCurrentPrice, decimals)
+		price.SipItemPrice = roundPrice(price.SipItemPrice, decimals)
+	}
+}
+
+// adminListRegions lists the region profiles
+// @Summary List region profiles
+// @Description Lists the markets shops can be created in, with the currency, price precision, address and phone format, payment methods and carriers their data uses
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} RegionProfileListResponse "Region profiles"
+// @Router /admin/regions [get]
+func adminListRegions(c *fiber.Ctx) error {
+	list := make([]RegionProfile, 0, len(regionProfiles))
+	for _, profile := range regionProfiles {
+		list = append(list, profile)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Region < list[j].Region })
+	return c.JSON(RegionProfileListResponse{RegionList: list})
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	scenarioRunning   = "running"
+	scenarioFinished  = "finished"
+	scenarioFailed    = "failed"
+	scenarioCancelled = "cancelled"
+
+	stepPending = "pending"
+	stepDone    = "done"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// statusActions are the scenario actions that move an order to a new
+// status, standing in for buyer and logistics events. Stock moves with the
+// order as setOrderStatus allows.
+var statusActions = map[string]string{
+	"mark_shipped":   "SHIPPED",
+	"deliver":        "TO_CONFIRM_RECEIVE",
+	"complete":       "COMPLETED",
+	"request_cancel": "IN_CANCEL",
+	"request_return": "TO_RETURN",
+}
+
+var errScenarioNotFound = errors.New("scenario run not found")
+
+// ScenarioStep is one event in a scenario. At is an offset from the
+// scenario start such as "0", "+5m", "+2h" or "+3d12h".
+type ScenarioStep struct {
+	At              string            `json:"at" example:"+5m"`
+	Action          string            `json:"action" example:"pay"`
+	OrderSN         string            `json:"order_sn,omitempty" example:"2404098R48U37H"`
+	OrderStatus     string            `json:"order_status,omitempty" example:"UNPAID"`
+	ItemList        []CreateOrderItem `json:"item_list,omitempty"`
+	MessageToSeller string            `json:"message_to_seller,omitempty" example:"Please pack carefully"`
+	TrackingNumber  string            `json:"tracking_number,omitempty" example:"TH0123456789"`
+	CancelBy        string            `json:"cancel_by,omitempty" example:"buyer"`
+	Reason          string            `json:"reason,omitempty" example:"CHANGE_OF_MIND"`
+}
+
+// Scenario is a scripted order timeline. Steps without an order_sn act on
+// the order the previous step used. Start is a unix time; 0 starts the
+// scenario at the clock's current time.
+type Scenario struct {
+	Name   string         `json:"name" example:"paid, shipped, returned"`
+	ShopID int64          `json:"shop_id" example:"789012"`
+	Start  int64          `json:"start" example:"0"`
+	Steps  []ScenarioStep `json:"steps"`
+}
+
+type ScenarioStepResult struct {
+	ScenarioStep
+	DueTime int64  `json:"due_time" example:"1758275138"`
+	Status  string `json:"status" example:"done"`
+	Error   string `json:"error" example:""`
+}
+
+type ScenarioRun struct {
+	ID     int64                `json:"id" example:"1"`
+	Name   string               `json:"name" example:"paid, shipped, returned"`
+	ShopID int64                `json:"shop_id" example:"789012"`
+	Start  int64                `json:"start" example:"1758274838"`
+	Status string               `json:"status" example:"running"`
+	Steps  []ScenarioStepResult `json:"steps"`
+}
+
+type ScenarioRunListResponse struct {
+	RunList []ScenarioRun `json:"run_list"`
+}
+
+var offsetPattern = regexp.MustCompile(`^\+?(?:(\d+)d)?(.*)$`)
+
+// parseOffset parses a step offset. It accepts Go durations plus a leading
+// day count, which time.ParseDuration does not.
+func parseOffset(offset string) (time.Duration, error) {
+	m := offsetPattern.FindStringSubmatch(offset)
+	if m == nil {
+		return 0, fmt.Errorf("invalid offset %q", offset)
+	}
+
+	var d time.Duration
+	if m[1] != "" {
+		days, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q", offset)
+		}
+		d = time.Duration(days) * 24 * time.Hour
+	}
+	if m[2] != "" && m[2] != "0" {
+		rest, err := time.ParseDuration(m[2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q", offset)
+		}
+		d += rest
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("offset %q is negative", offset)
+	}
+	return d, nil
+}
+
+func (s Scenario) validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("steps is required")
+	}
+	if _, ok := tenants.shop(resolveShopID(s.ShopID)); !ok {
+		return errShopNotFound
+	}
+
+	haveOrder := false
+	var last time.Duration
+	for i, step := range s.Steps {
+		offset, err := parseOffset(step.At)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if offset < last {
+			return fmt.Errorf("step %d: steps must be in time order", i+1)
+		}
+		last = offset
+		switch step.Action {
+		case "create_order":
+			if len(step.ItemList) == 0 {
+				return fmt.Errorf("step %d: item_list is required for create_order", i+1)
+			}
+			if _, ok := orderStatuses[step.OrderStatus]; step.OrderStatus != "" && !ok {
+				return fmt.Errorf("step %d: %w %s", i+1, errUnknownStatus, step.OrderStatus)
+			}
+			haveOrder = true
+			continue
+		case "pay", "ship", "cancel":
+		case "set_status":
+			if step.OrderStatus == "" {
+				return fmt.Errorf("step %d: order_status is required for set_status", i+1)
+			}
+			if _, ok := orderStatuses[step.OrderStatus]; !ok {
+				return fmt.Errorf("step %d: %w %s", i+1, errUnknownStatus, step.OrderStatus)
+			}
+		default:
+			if _, ok := statusActions[step.Action]; !ok {
+				return fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+			}
+		}
+		if step.OrderSN != "" {
+			haveOrder = true
+		}
+		if !haveOrder {
+			return fmt.Errorf("step %d: order_sn is required before any create_order step", i+1)
+		}
+	}
+	return nil
+}
+
+// scenarioRunner plays scenarios against the virtual clock. A run's next
+// step is a clock timer, so a run advances as fast as the clock is moved.
+type scenarioRunner struct {
+	mu     sync.Mutex
+	runs   []*ScenarioRun
+	nextID int64
+}
+
+var scenarios = &scenarioRunner{nextID: 1}
+
+// start validates a scenario and schedules its first step.
+func (r *scenarioRunner) start(scenario Scenario) (ScenarioRun, error) {
+	if err := scenario.validate(); err != nil {
+		return ScenarioRun{}, err
+	}
+
+	start := clock.Now()
+	if scenario.Start != 0 {
+		start = time.Unix(scenario.Start, 0)
+	}
+
+	r.mu.Lock()
+	run := &ScenarioRun{
+		ID:     r.nextID,
+		Name:   scenario.Name,
+		ShopID: resolveShopID(scenario.ShopID),
+		Start:  start.Unix(),
+		Status: scenarioRunning,
+	}
+	r.nextID++
+	for _, step := range scenario.Steps {
+		offset, _ := parseOffset(step.At)
+		run.Steps = append(run.Steps, ScenarioStepResult{
+			ScenarioStep: step,
+			DueTime:      start.Add(offset).Unix(),
+			Status:       stepPending,
+		})
+	}
+	r.runs = append(r.runs, run)
+	snapshot := copyScenarioRun(run)
+	r.mu.Unlock()
+
+	if len(snapshot.Steps) > 0 {
+		clock.schedule(time.Unix(snapshot.Steps[0].DueTime, 0), func(at time.Time) {
+			r.runStep(run, 0, at)
+		})
+	}
+	return snapshot, nil
+}
+
+// runStep plays step i of a run, then every later step that is already
+// due, in order. Only the next step still to come is scheduled, so a run's
+// steps never overlap even when the ticker and an admin advance fire
+// timers at the same time.
+func (r *scenarioRunner) runStep(run *ScenarioRun, i int, at time.Time) {
+	for r.playRunStep(run, i, at) && i+1 < len(run.Steps) {
+		i++
+		due := time.Unix(run.Steps[i].DueTime, 0)
+		if due.After(clock.Now()) {
+			next := i
+			clock.schedule(due, func(at time.Time) {
+				r.runStep(run, next, at)
+			})
+			return
+		}
+		at = due
+	}
+}
+
+// playRunStep plays one step of a run and reports whether the run goes on.
+// Once a step fails, or the run is cancelled, the remaining steps are
+// skipped.
+func (r *scenarioRunner) playRunStep(run *ScenarioRun, i int, at time.Time) bool {
+	r.mu.Lock()
+	if run.Status != scenarioRunning {
+		skipSteps(run.Steps[i:])
+		r.mu.Unlock()
+		return false
+	}
+	step := run.Steps[i].ScenarioStep
+	if step.OrderSN == "" && step.Action != "create_order" {
+		step.OrderSN = currentOrderSN(run.Steps[:i])
+	}
+	r.mu.Unlock()
+
+	orderSN, err := playStep(run.ShopID, step, at)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run.Steps[i].OrderSN = orderSN
+	if err != nil {
+		run.Steps[i].Status = stepFailed
+		run.Steps[i].Error = err.Error()
+		run.Status = scenarioFailed
+		skipSteps(run.Steps[i+1:])
+		return false
+	}
+	run.Steps[i].Status = stepDone
+	if i == len(run.Steps)-1 {
+		run.Status = scenarioFinished
+	}
+	return true
+}
+
+// skipSteps marks the steps that have not played as skipped. Callers must
+// hold r.mu.
+func skipSteps(steps []ScenarioStepResult) {
+	for i := range steps {
+		if steps[i].Status == stepPending {
+			steps[i].Status = stepSkipped
+		}
+	}
+}
+
+// currentOrderSN returns the order the last played step used.
+func currentOrderSN(steps []ScenarioStepResult) string {
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].OrderSN != "" {
+			return steps[i].OrderSN
+		}
+	}
+	return ""
+}
+
+// playStep applies a step to a shop's orders and returns the order it acted
+// on.
+func playStep(shopID int64, step ScenarioStep, at time.Time) (string, error) {
+	var (
+		order OrderDetail
+		err   error
+	)
+	switch step.Action {
+	case "create_order":
+		order, err = buildOrder(CreateOrderRequest{
+			ShopID:          shopID,
+			OrderSN:         step.OrderSN,
+			OrderStatus:     step.OrderStatus,
+			MessageToSeller: step.MessageToSeller,
+			ItemList:        step.ItemList,
+		}, at)
+		if err == nil {
+			order, err = store.createOrder(shopID, order)
+		}
+		if err != nil {
+			return step.OrderSN, err
+		}
+		return order.OrderSN, nil
+	case "pay":
+		_, err = store.payOrder(shopID, step.OrderSN, at)
+	case "ship":
+		trackingNo := step.TrackingNumber
+		if trackingNo == "" {
+			shop, _ := tenants.shop(shopID)
+			trackingNo = regionProfile(shop.Region).trackingNumber()
+		}
+		_, err = store.shipOrder(shopID, step.OrderSN, trackingNo, at)
+	case "set_status":
+		if step.OrderStatus != "CANCELLED" {
+			_, err = store.setOrderStatus(shopID, step.OrderSN, step.OrderStatus, at)
+			break
+		}
+		fallthrough
+	case "cancel":
+		cancelBy := step.CancelBy
+		if cancelBy == "" {
+			cancelBy = "buyer"
+		}
+		_, err = store.cancelOrder(shopID, step.OrderSN, cancelBy, step.Reason, at)
+	default:
+		_, err = store.setOrderStatus(shopID, step.OrderSN, statusActions[step.Action], at)
+	}
+	return step.OrderSN, err
+}
+
+func (r *scenarioRunner) list() []ScenarioRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := []ScenarioRun{}
+	for _, run := range r.runs {
+		list = append(list, copyScenarioRun(run))
+	}
+	return list
+}
+
+func (r *scenarioRunner) get(id int64) (ScenarioRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, run := range r.runs {
+		if run.ID == id {
+			return copyScenarioRun(run), nil
+		}
+	}
+	return ScenarioRun{}, errScenarioNotFound
+}
+
+// cancel stops a run; its pending steps are skipped when they fall due.
+func (r *scenarioRunner) cancel(id int64) (ScenarioRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, run := range r.runs {
+		if run.ID == id {
+			if run.Status == scenarioRunning {
+				run.Status = scenarioCancelled
+				skipSteps(run.Steps)
+			}
+			return copyScenarioRun(run), nil
+		}
+	}
+	return ScenarioRun{}, errScenarioNotFound
+}
+
+func copyScenarioRun(run *ScenarioRun) ScenarioRun {
+	c := *run
+	c.Steps = append([]ScenarioStepResult{}, run.Steps...)
+	return c
+}
+
+// loadScenarioFile starts the scenarios in a JSON file holding either one
+// scenario or an array of them.
+func loadScenarioFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var list []Scenario
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &list)
+	} else {
+		list = make([]Scenario, 1)
+		err = json.Unmarshal(data, &list[0])
+	}
+	if err != nil {
+		return fmt.Errorf("parse scenarios %s: %w", path, err)
+	}
+
+	for _, scenario := range list {
+		if _, err := scenarios.start(scenario); err != nil {
+			return fmt.Errorf("scenario %q: %w", scenario.Name, err)
+		}
+	}
+	return nil
+}
+
+func scenarioError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errScenarioNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminStartScenario starts a scripted order timeline
+// @Summary Start scenario
+// @Description Schedules a scenario's steps on the virtual clock. Actions are create_order, pay, ship, cancel, mark_shipped, deliver, complete, request_cancel, request_return and set_status. Each step changes the stored order and fires the usual push messages when the clock reaches it. Stock moves with the order: shipping or completing a READY_TO_SHIP order deducts its reserved stock and starts its auto-complete timer, and cancelling releases it. Steps that would ship an order before it is ready, cancel a shipped order or move a cancelled one fail, as does a set_status to an unknown status.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body Scenario true "Scenario to play"
+// @Success 200 {object} ScenarioRun "Scheduled run"
+// @Failure 400 {object} map[string]interface{} "Invalid scenario"
+// @Router /admin/scenarios [post]
+func adminStartScenario(c *fiber.Ctx) error {
+	var scenario Scenario
+
+	if err := c.BodyParser(&scenario); err != nil {
+		return scenarioError(c, errors.New("Invalid request body"))
+	}
+
+	run, err := scenarios.start(scenario)
+	if err != nil {
+		return scenarioError(c, err)
+	}
+	return c.JSON(run)
+}
+
+// adminListScenarios lists scenario runs
+// @Summary List scenario runs
+// @Description Lists every scenario run with the status of each step
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ScenarioRunListResponse "Runs"
+// @Router /admin/scenarios [get]
+func adminListScenarios(c *fiber.Ctx) error {
+	return c.JSON(ScenarioRunListResponse{RunList: scenarios.list()})
+}
+
+// adminGetScenario returns one scenario run
+// @Summary Get scenario run
+// @Description Returns one scenario run with the status of each step
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Run ID" example(1)
+// @Success 200 {object} ScenarioRun "Run"
+// @Failure 404 {object} map[string]interface{} "Unknown run"
+// @Router /admin/scenarios/{id} [get]
+func adminGetScenario(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return scenarioError(c, errScenarioNotFound)
+	}
+
+	run, err := scenarios.get(id)
+	if err != nil {
+		return scenarioError(c, err)
+	}
+	return c.JSON(run)
+}
+
+// adminCancelScenario stops a scenario run
+// @Summary Cancel scenario run
+// @Description Stops a scenario run; steps that have not played yet are skipped
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Run ID" example(1)
+// @Success 200 {object} ScenarioRun "Run"
+// @Failure 404 {object} map[string]interface{} "Unknown run"
+// @Router /admin/scenarios/{id} [delete]
+func adminCancelScenario(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return scenarioError(c, errScenarioNotFound)
+	}
+
+	run, err := scenarios.cancel(id)
+	if err != nil {
+		return scenarioError(c, err)
+	}
+	return c.JSON(run)
+}
@@ -0,0 +1,833 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultChatPageSize = 25
+	maxChatPageSize     = 60
+)
+
+var (
+	errConversationNotFound = errors.New("conversation not found")
+	errBuyerNotFound        = errors.New("buyer not found")
+	errInvalidChatMessage   = errors.New("invalid message")
+)
+
+type SendMessageContent struct {
+	Text             string `json:"text,omitempty" example:"Your parcel ships today"`
+	ImageURL         string `json:"image_url,omitempty" example:"http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"`
+	StickerID        string `json:"sticker_id,omitempty" example:"1"`
+	StickerPackageID string `json:"sticker_package_id,omitempty" example:"1000"`
+	ItemID           int64  `json:"item_id,omitempty" example:"34001"`
+	OrderSN          string `json:"order_sn,omitempty" example:"2404098R48U37H"`
+}
+
+type ChatMessageContent struct {
+	Text             string `json:"text,omitempty" example:"Hi, is this still available?"`
+	URL              string `json:"url,omitempty" example:"http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"`
+	StickerID        string `json:"sticker_id,omitempty" example:"1"`
+	StickerPackageID string `json:"sticker_package_id,omitempty" example:"1000"`
+	ItemID           int64  `json:"item_id,omitempty" example:"34001"`
+	ShopID           int64  `json:"shop_id,omitempty" example:"789012"`
+	OrderSN          string `json:"order_sn,omitempty" example:"2404098R48U37H"`
+}
+
+type ChatMessage struct {
+	MessageID        string             `json:"message_id" example:"2000000000000000001"`
+	ConversationID   string             `json:"conversation_id" example:"1000000001"`
+	FromID           int64              `json:"from_id" example:"121144654"`
+	ToID             int64              `json:"to_id" example:"789012"`
+	FromShopID       int64              `json:"from_shop_id" example:"0"`
+	ToShopID         int64              `json:"to_shop_id" example:"789012"`
+	MessageType      string             `json:"message_type" example:"text"`
+	Content          ChatMessageContent `json:"content"`
+	CreatedTimestamp int64              `json:"created_timestamp" example:"1758274838"`
+	Region           string             `json:"region" example:"TH"`
+	Status           string             `json:"status" example:"normal"`
+	Source           string             `json:"source" example:"api"`
+}
+
+type Conversation struct {
+	ConversationID       string             `json:"conversation_id" example:"1000000001"`
+	ToID                 int64              `json:"to_id" example:"121144654"`
+	ToName               string             `json:"to_name" example:"konlawatkkk"`
+	ToAvatar             string             `json:"to_avatar" example:""`
+	ShopID               int64              `json:"shop_id" example:"789012"`
+	UnreadCount          int                `json:"unread_count" example:"1"`
+	Pinned               bool               `json:"pinned" example:"false"`
+	LastReadMessageID    string             `json:"last_read_message_id" example:"0"`
+	LatestMessageID      string             `json:"latest_message_id" example:"2000000000000000001"`
+	LatestMessageType    string             `json:"latest_message_type" example:"text"`
+	LatestMessageContent ChatMessageContent `json:"latest_message_content"`
+	LatestMessageFromID  int64              `json:"latest_message_from_id" example:"121144654"`
+	LastMessageTimestamp int64              `json:"last_message_timestamp" example:"1758274838000000000"`
+}
+
+type ConversationPageCursor struct {
+	NextMessageTimeNano string `json:"next_message_time_nano" example:"1758274838000000000"`
+	ConversationID      string `json:"conversation_id" example:"1000000001"`
+}
+
+type ConversationPageResult struct {
+	PageSize   int                    `json:"page_size" example:"25"`
+	NextCursor ConversationPageCursor `json:"next_cursor"`
+	More       bool                   `json:"more" example:"false"`
+}
+
+type ConversationListResult struct {
+	PageResult    ConversationPageResult `json:"page_result"`
+	Conversations []Conversation         `json:"conversations"`
+}
+
+type GetConversationListResponse struct {
+	RequestID string                  `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string                  `json:"error" example:""`
+	Message   string                  `json:"message" example:""`
+	Response  *ConversationListResult `json:"response,omitempty"`
+}
+
+type MessagePageResult struct {
+	PageSize   int    `json:"page_size" example:"25"`
+	NextOffset string `json:"next_offset" example:""`
+}
+
+type MessageListResult struct {
+	Messages   []ChatMessage     `json:"messages"`
+	PageResult MessagePageResult `json:"page_result"`
+}
+
+type GetMessageResponse struct {
+	RequestID string             `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string             `json:"error" example:""`
+	Message   string             `json:"message" example:""`
+	Response  *MessageListResult `json:"response,omitempty"`
+}
+
+type SendMessageRequest struct {
+	ToID        int64              `json:"to_id" example:"121144654"`
+	MessageType string             `json:"message_type" example:"text"`
+	Content     SendMessageContent `json:"content"`
+}
+
+type SendMessageResponse struct {
+	RequestID string       `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string       `json:"error" example:""`
+	Message   string       `json:"message" example:""`
+	Response  *ChatMessage `json:"response,omitempty"`
+}
+
+type ReadConversationRequest struct {
+	ConversationID    int64  `json:"conversation_id" example:"1000000001"`
+	LastReadMessageID string `json:"last_read_message_id" example:"2000000000000000001"`
+}
+
+type ReadConversationResult struct {
+	UnreadCount    int    `json:"unread_count" example:"0"`
+	ConversationID string `json:"conversation_id" example:"1000000001"`
+}
+
+type ReadConversationResponse struct {
+	RequestID string                  `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string                  `json:"error" example:""`
+	Message   string                  `json:"message" example:""`
+	Response  *ReadConversationResult `json:"response,omitempty"`
+}
+
+type ChatImageResult struct {
+	URL       string `json:"url" example:"http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"`
+	Thumbnail string `json:"thumbnail" example:"http://localhost:3001/media/images/th-11134201-7r98o-lxyz12ab34cd56"`
+	Height    int    `json:"height" example:"100"`
+	Width     int    `json:"width" example:"100"`
+}
+
+type UploadChatImageResponse struct {
+	RequestID string           `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string           `json:"error" example:""`
+	Message   string           `json:"message" example:""`
+	Response  *ChatImageResult `json:"response,omitempty"`
+}
+
+type InjectChatMessageRequest struct {
+	ShopID        int64              `json:"shop_id" example:"789012"`
+	OrderSN       string             `json:"order_sn,omitempty" example:"2404098R48U37H"`
+	BuyerUserID   int64              `json:"buyer_user_id,omitempty" example:"121144654"`
+	BuyerUsername string             `json:"buyer_username,omitempty" example:"konlawatkkk"`
+	MessageType   string             `json:"message_type" example:"text"`
+	Content       SendMessageContent `json:"content"`
+}
+
+type ChatMessagePush struct {
+	Type    string      `json:"type" example:"message"`
+	Content ChatMessage `json:"content"`
+}
+
+// chatConversation is a conversation with its messages, oldest first.
+type chatConversation struct {
+	Conversation
+	messages []ChatMessage
+}
+
+type chatBuyer struct {
+	shopID      int64
+	buyerUserID int64
+}
+
+// chatStore keeps each shop's conversations with its buyers. Conversation
+// and message IDs both only ever grow, so message IDs order messages.
+type chatStore struct {
+	mu                 sync.Mutex
+	conversations      map[int64]*chatConversation
+	byBuyer            map[chatBuyer]int64
+	nextConversationID int64
+	nextMessageID      int64
+}
+
+var chats = &chatStore{
+	conversations:      make(map[int64]*chatConversation),
+	byBuyer:            make(map[chatBuyer]int64),
+	nextConversationID: 1000000001,
+	nextMessageID:      2000000000000000001,
+}
+
+// conversation returns a shop's conversation. Callers must hold s.mu.
+func (s *chatStore) conversation(shopID, conversationID int64) (*chatConversation, bool) {
+	conv, ok := s.conversations[conversationID]
+	if !ok || conv.ShopID != shopID {
+		return nil, false
+	}
+	return conv, true
+}
+
+// conversationCursor is where a conversation page ends: the last message
+// time of its last conversation and, to tell apart conversations whose last
+// messages arrived in the same nanosecond, that conversation's ID.
+type conversationCursor struct {
+	timestamp      int64
+	conversationID int64
+}
+
+// compare returns 1 if a conversation is more recent than the cursor, -1
+// if it is older and 0 if it is the cursor's own. Without a conversation ID
+// only the timestamps are compared.
+func (cursor conversationCursor) compare(timestamp, conversationID int64) int {
+	switch {
+	case timestamp > cursor.timestamp:
+		return 1
+	case timestamp < cursor.timestamp:
+		return -1
+	case cursor.conversationID == 0 || conversationID == cursor.conversationID:
+		return 0
+	case conversationID > cursor.conversationID:
+		return 1
+	}
+	return -1
+}
+
+// list pages through a shop's conversations, most recent first. Older
+// pages hold conversations after the cursor in that order, latest pages
+// those before it; a zero cursor starts from the newest.
+func (s *chatStore) list(shopID int64, kind, direction string, cursor conversationCursor, pageSize int) ([]Conversation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list []Conversation
+	for id, conv := range s.conversations {
+		if conv.ShopID != shopID {
+			continue
+		}
+		if (kind == "pinned" && !conv.Pinned) || (kind == "unread" && conv.UnreadCount == 0) {
+			continue
+		}
+		if cursor.timestamp != 0 {
+			order := cursor.compare(conv.LastMessageTimestamp, id)
+			if (direction == "older" && order >= 0) || (direction == "latest" && order <= 0) {
+				continue
+			}
+		}
+		list = append(list, conv.Conversation)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].LastMessageTimestamp != list[j].LastMessageTimestamp {
+			return list[i].LastMessageTimestamp > list[j].LastMessageTimestamp
+		}
+		return list[i].ConversationID > list[j].ConversationID
+	})
+
+	if len(list) > pageSize {
+		return list[:pageSize], true
+	}
+	return list, false
+}
+
+// messages pages through a conversation's messages, newest first, starting
+// before the message ID offset, or at the newest when offset is empty.
+func (s *chatStore) messages(shopID, conversationID int64, offset string, pageSize int) ([]ChatMessage, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, ok := s.conversation(shopID, conversationID)
+	if !ok {
+		return nil, "", errConversationNotFound
+	}
+
+	end := len(conv.messages)
+	if offset != "" {
+		end = messageIndex(conv.messages, offset)
+		if end < 0 {
+			return nil, "", fmt.Errorf("%w: offset %s is not a message in this conversation", errInvalidChatMessage, offset)
+		}
+	}
+
+	messages := []ChatMessage{}
+	for i := end - 1; i >= 0 && len(messages) < pageSize; i-- {
+		messages = append(messages, conv.messages[i])
+	}
+	nextOffset := ""
+	if end-len(messages) > 0 {
+		nextOffset = messages[len(messages)-1].MessageID
+	}
+	return messages, nextOffset, nil
+}
+
+// add appends a message between a shop and a buyer, opening their
+// conversation if this is the first. Messages from the buyer count as
+// unread and are pushed to the shop.
+func (s *chatStore) add(shop Shop, buyerUserID int64, buyerUsername string, fromBuyer bool, messageType string, content ChatMessageContent) ChatMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := chatBuyer{shopID: shop.ShopID, buyerUserID: buyerUserID}
+	conv, ok := s.conversations[s.byBuyer[key]]
+	if !ok {
+		id := s.nextConversationID
+		s.nextConversationID++
+		conv = &chatConversation{Conversation: Conversation{
+			ConversationID:    strconv.FormatInt(id, 10),
+			ToID:              buyerUserID,
+			ToName:            buyerUsername,
+			ShopID:            shop.ShopID,
+			LastReadMessageID: "0",
+		}}
+		s.conversations[id] = conv
+		s.byBuyer[key] = id
+	}
+
+	now := clock.Now()
+	message := ChatMessage{
+		MessageID:        strconv.FormatInt(s.nextMessageID, 10),
+		ConversationID:   conv.ConversationID,
+		FromID:           shop.ShopID,
+		ToID:             buyerUserID,
+		FromShopID:       shop.ShopID,
+		MessageType:      messageType,
+		Content:          content,
+		CreatedTimestamp: now.Unix(),
+		Region:           shop.Region,
+		Status:           "normal",
+		Source:           "api",
+	}
+	s.nextMessageID++
+	if fromBuyer {
+		message.FromID, message.ToID = buyerUserID, shop.ShopID
+		message.FromShopID, message.ToShopID = 0, shop.ShopID
+		message.Source = "app"
+		conv.UnreadCount++
+	}
+
+	conv.messages = append(conv.messages, message)
+	conv.LatestMessageID = message.MessageID
+	conv.LatestMessageType = message.MessageType
+	conv.LatestMessageContent = message.Content
+	conv.LatestMessageFromID = message.FromID
+	conv.LastMessageTimestamp = now.UnixNano()
+
+	if fromBuyer {
+		webhooks.push(pushWebchat, shop.ShopID, ChatMessagePush{Type: "message", Content: message})
+	}
+	return message
+}
+
+// buyer returns the buyer a shop has a conversation with.
+func (s *chatStore) buyer(shopID, buyerUserID int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, ok := s.conversations[s.byBuyer[chatBuyer{shopID: shopID, buyerUserID: buyerUserID}]]
+	if !ok {
+		return "", false
+	}
+	return conv.ToName, true
+}
+
+// read marks a conversation read up to a message, leaving later buyer
+// messages unread.
+func (s *chatStore) read(shopID, conversationID int64, lastReadMessageID string) (Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, ok := s.conversation(shopID, conversationID)
+	if !ok {
+		return Conversation{}, errConversationNotFound
+	}
+	i := messageIndex(conv.messages, lastReadMessageID)
+	if i < 0 {
+		return Conversation{}, fmt.Errorf("%w: last_read_message_id %s is not a message in this conversation", errInvalidChatMessage, lastReadMessageID)
+	}
+
+	conv.LastReadMessageID = lastReadMessageID
+	conv.UnreadCount = 0
+	for _, message := range conv.messages[i+1:] {
+		if message.FromShopID == 0 {
+			conv.UnreadCount++
+		}
+	}
+	return conv.Conversation, nil
+}
+
+func messageIndex(messages []ChatMessage, messageID string) int {
+	for i, message := range messages {
+		if message.MessageID == messageID {
+			return i
+		}
+	}
+	return -1
+}
+
+// chatBuyerName finds the username of a buyer a shop can message: one it
+// already has a conversation with, or one who has ordered from it.
+func chatBuyerName(shopID, buyerUserID int64) (string, error) {
+	if name, ok := chats.buyer(shopID, buyerUserID); ok {
+		return name, nil
+	}
+	if name, ok := store.buyerUsername(shopID, buyerUserID); ok {
+		return name, nil
+	}
+	return "", errBuyerNotFound
+}
+
+// chatContent checks a message's content against its type, resolving the
+// item or order it shares in the shop.
+func chatContent(shopID int64, messageType string, req SendMessageContent) (ChatMessageContent, error) {
+	switch messageType {
+	case "text":
+		if strings.TrimSpace(req.Text) == "" {
+			return ChatMessageContent{}, fmt.Errorf("%w: text is required for text messages", errInvalidChatMessage)
+		}
+		return ChatMessageContent{Text: req.Text}, nil
+	case "image":
+		if req.ImageURL == "" {
+			return ChatMessageContent{}, fmt.Errorf("%w: image_url is required for image messages", errInvalidChatMessage)
+		}
+		return ChatMessageContent{URL: req.ImageURL}, nil
+	case "sticker":
+		if req.StickerID == "" || req.StickerPackageID == "" {
+			return ChatMessageContent{}, fmt.Errorf("%w: sticker_id and sticker_package_id are required for sticker messages", errInvalidChatMessage)
+		}
+		return ChatMessageContent{StickerID: req.StickerID, StickerPackageID: req.StickerPackageID}, nil
+	case "item":
+		if _, ok := store.getItem(shopID, req.ItemID); !ok {
+			return ChatMessageContent{}, errItemNotFound
+		}
+		return ChatMessageContent{ItemID: req.ItemID, ShopID: shopID}, nil
+	case "order":
+		if _, ok := store.getOrder(shopID, req.OrderSN); !ok {
+			return ChatMessageContent{}, errOrderNotFound
+		}
+		return ChatMessageContent{OrderSN: req.OrderSN, ShopID: shopID}, nil
+	}
+	return ChatMessageContent{}, fmt.Errorf("%w: message_type must be text, image, sticker, item or order", errInvalidChatMessage)
+}
+
+// chatPageSize checks a page_size parameter, defaulting it when unset.
+func chatPageSize(pageSize int) (int, error) {
+	if pageSize == 0 {
+		return defaultChatPageSize, nil
+	}
+	if pageSize < 0 || pageSize > maxChatPageSize {
+		return 0, fmt.Errorf("page_size must be between 1 and %d", maxChatPageSize)
+	}
+	return pageSize, nil
+}
+
+// getConversationList lists the shop's chat conversations
+// @Summary Get conversation list
+// @Description Lists the shop's conversations with buyers, most recent first. With direction older, next_timestamp_nano and next_conversation_id page back to the conversations after that cursor; with latest, forward to those before it. Conversations whose last messages share a timestamp are ordered by conversation ID.
+// @Tags SellerChat
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param direction query string true "latest or older" example("older")
+// @Param type query string true "all, pinned or unread" example("all")
+// @Param next_timestamp_nano query int64 false "Cursor from the previous page's next_message_time_nano" example(1758274838000000000)
+// @Param next_conversation_id query int64 false "Cursor from the previous page's conversation_id" example(1000000001)
+// @Param page_size query int false "Conversations per page, at most 60" example(25)
+// @Success 200 {object} GetConversationListResponse "Success response"
+// @Failure 400 {object} GetConversationListResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/sellerchat/get_conversation_list [get]
+func getConversationList(c *fiber.Ctx) error {
+	direction := c.Query("direction")
+	if direction != "latest" && direction != "older" {
+		return c.Status(400).JSON(GetConversationListResponse{
+			Error:   "error_param",
+			Message: "direction must be latest or older",
+		})
+	}
+	kind := c.Query("type")
+	if kind != "all" && kind != "pinned" && kind != "unread" {
+		return c.Status(400).JSON(GetConversationListResponse{
+			Error:   "error_param",
+			Message: "type must be all, pinned or unread",
+		})
+	}
+	pageSize, err := chatPageSize(c.QueryInt("page_size"))
+	if err != nil {
+		return c.Status(400).JSON(GetConversationListResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+	var cursor conversationCursor
+	if s := c.Query("next_timestamp_nano"); s != "" {
+		if cursor.timestamp, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return c.Status(400).JSON(GetConversationListResponse{
+				Error:   "error_param",
+				Message: "next_timestamp_nano must be an integer",
+			})
+		}
+	}
+	if s := c.Query("next_conversation_id"); s != "" {
+		if cursor.conversationID, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return c.Status(400).JSON(GetConversationListResponse{
+				Error:   "error_param",
+				Message: "next_conversation_id must be an integer",
+			})
+		}
+	}
+
+	conversations, more := chats.list(currentShop(c).ShopID, kind, direction, cursor, pageSize)
+	result := &ConversationListResult{
+		PageResult:    ConversationPageResult{PageSize: pageSize, More: more},
+		Conversations: []Conversation{},
+	}
+	if len(conversations) > 0 {
+		last := conversations[len(conversations)-1]
+		result.PageResult.NextCursor = ConversationPageCursor{
+			NextMessageTimeNano: strconv.FormatInt(last.LastMessageTimestamp, 10),
+			ConversationID:      last.ConversationID,
+		}
+		result.Conversations = conversations
+	}
+
+	return c.JSON(GetConversationListResponse{
+		RequestID: newRequestID(),
+		Response:  result,
+	})
+}
+
+// getMessage lists the messages in a conversation
+// @Summary Get messages
+// @Description Lists a conversation's messages, newest first. Pass the previous page's next_offset as offset to page back to older messages.
+// @Tags SellerChat
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param conversation_id query int64 true "Conversation ID" example(1000000001)
+// @Param offset query string false "Message ID to page back from" example("2000000000000000001")
+// @Param page_size query int false "Messages per page, at most 60" example(25)
+// @Success 200 {object} GetMessageResponse "Success response"
+// @Failure 400 {object} GetMessageResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} GetMessageResponse "Unknown conversation"
+// @Router /api/v2/sellerchat/get_message [get]
+func getMessage(c *fiber.Ctx) error {
+	conversationID, err := strconv.ParseInt(c.Query("conversation_id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(GetMessageResponse{
+			Error:   "error_param",
+			Message: "conversation_id is required",
+		})
+	}
+	pageSize, err := chatPageSize(c.QueryInt("page_size"))
+	if err != nil {
+		return c.Status(400).JSON(GetMessageResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	messages, nextOffset, err := chats.messages(currentShop(c).ShopID, conversationID, c.Query("offset"), pageSize)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(GetMessageResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(GetMessageResponse{
+		RequestID: newRequestID(),
+		Response: &MessageListResult{
+			Messages:   messages,
+			PageResult: MessagePageResult{PageSize: pageSize, NextOffset: nextOffset},
+		},
+	})
+}
+
+// sendMessage sends a message to a buyer
+// @Summary Send message
+// @Description Sends a text, image, sticker, item or order message to a buyer the shop has a conversation with or an order from
+// @Tags SellerChat
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body SendMessageRequest true "Message to send"
+// @Success 200 {object} SendMessageResponse "Success response"
+// @Failure 400 {object} SendMessageResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} SendMessageResponse "Unknown buyer, item or order"
+// @Router /api/v2/sellerchat/send_message [post]
+func sendMessage(c *fiber.Ctx) error {
+	var req SendMessageRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(SendMessageResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ToID == 0 {
+		return c.Status(400).JSON(SendMessageResponse{
+			Error:   "error_param",
+			Message: "to_id is required",
+		})
+	}
+
+	shop := currentShop(c)
+	buyerUsername, err := chatBuyerName(shop.ShopID, req.ToID)
+	var content ChatMessageContent
+	if err == nil {
+		content, err = chatContent(shop.ShopID, req.MessageType, req.Content)
+	}
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(SendMessageResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	message := chats.add(shop, req.ToID, buyerUsername, false, req.MessageType, content)
+	return c.JSON(SendMessageResponse{
+		RequestID: newRequestID(),
+		Response:  &message,
+	})
+}
+
+// readConversation marks a conversation as read
+// @Summary Read conversation
+// @Description Marks a conversation read up to a message; buyer messages after it stay unread
+// @Tags SellerChat
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body ReadConversationRequest true "Conversation and last read message"
+// @Success 200 {object} ReadConversationResponse "Success response"
+// @Failure 400 {object} ReadConversationResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} ReadConversationResponse "Unknown conversation"
+// @Router /api/v2/sellerchat/read_conversation [post]
+func readConversation(c *fiber.Ctx) error {
+	var req ReadConversationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ReadConversationResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ConversationID == 0 || req.LastReadMessageID == "" {
+		return c.Status(400).JSON(ReadConversationResponse{
+			Error:   "error_param",
+			Message: "conversation_id and last_read_message_id are required",
+		})
+	}
+
+	conv, err := chats.read(currentShop(c).ShopID, req.ConversationID, req.LastReadMessageID)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(ReadConversationResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(ReadConversationResponse{
+		RequestID: newRequestID(),
+		Response: &ReadConversationResult{
+			UnreadCount:    conv.UnreadCount,
+			ConversationID: conv.ConversationID,
+		},
+	})
+}
+
+// uploadChatImage uploads an image to send in chat
+// @Summary Upload chat image
+// @Description Stores a JPG or PNG image and returns the URL to send as an image message
+// @Tags SellerChat
+// @Accept multipart/form-data
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param file formData file true "Image file, at most 10 MB"
+// @Success 200 {object} UploadChatImageResponse "Success response"
+// @Failure 400 {object} UploadChatImageResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/sellerchat/upload_image [post]
+func uploadChatImage(c *fiber.Ctx) error {
+	data, err := readFormFile(c, "file", maxImageSize)
+	if err != nil {
+		return c.Status(400).JSON(UploadChatImageResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	var ext string
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if ext == "" || err != nil {
+		return c.Status(400).JSON(UploadChatImageResponse{
+			Error:   "error_param",
+			Message: "file must be a JPG or PNG image",
+		})
+	}
+
+	imageID, err := media.saveImage(data, ext)
+	if err != nil {
+		return c.Status(500).JSON(UploadChatImageResponse{
+			Error:   "error_server",
+			Message: err.Error(),
+		})
+	}
+
+	url := media.imageURL(imageID)
+	return c.JSON(UploadChatImageResponse{
+		RequestID: newRequestID(),
+		Response: &ChatImageResult{
+			URL:       url,
+			Thumbnail: url,
+			Height:    config.Height,
+			Width:     config.Width,
+		},
+	})
+}
+
+// adminInjectChatMessage delivers a message from a buyer to a shop
+// @Summary Inject buyer chat message
+// @Description Adds a message from a buyer, named directly or as the buyer of order_sn, to their conversation with the shop, opening it if needed. The message counts as unread and is pushed to the webhook as a webchat push.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body InjectChatMessageRequest true "Buyer and message"
+// @Success 200 {object} ChatMessage "Injected message"
+// @Failure 400 {object} map[string]interface{} "Invalid message"
+// @Failure 404 {object} map[string]interface{} "Unknown shop, order, item or buyer"
+// @Router /admin/chat/messages [post]
+func adminInjectChatMessage(c *fiber.Ctx) error {
+	var req InjectChatMessageRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+
+	shop, ok := tenants.shop(resolveShopID(req.ShopID))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error":   "error_not_found",
+			"message": errShopNotFound.Error(),
+		})
+	}
+
+	buyerUserID, buyerUsername := req.BuyerUserID, req.BuyerUsername
+	if req.OrderSN != "" {
+		order, ok := store.getOrder(shop.ShopID, req.OrderSN)
+		if !ok {
+			return c.Status(404).JSON(fiber.Map{
+				"error":   "error_not_found",
+				"message": errOrderNotFound.Error(),
+			})
+		}
+		buyerUserID, buyerUsername = order.BuyerUserID, order.BuyerUsername
+	}
+	if buyerUserID == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "order_sn or buyer_user_id is required",
+		})
+	}
+	if buyerUsername == "" {
+		buyerUsername = fmt.Sprintf("buyer%d", buyerUserID)
+		if name, err := chatBuyerName(shop.ShopID, buyerUserID); err == nil {
+			buyerUsername = name
+		}
+	}
+
+	messageType := req.MessageType
+	if messageType == "" {
+		messageType = "text"
+	}
+	content, err := chatContent(shop.ShopID, messageType, req.Content)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(fiber.Map{
+			"error":   code,
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(chats.add(shop, buyerUserID, buyerUsername, true, messageType, content))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestChatStoreListPagesThroughTies(t *testing.T) {
+	const timestamp = 1758274838000000000
+	s := &chatStore{conversations: make(map[int64]*chatConversation)}
+	for id := int64(1000000001); id <= 1000000005; id++ {
+		stamp := int64(timestamp)
+		if id == 1000000005 {
+			stamp -= 1
+		}
+		s.conversations[id] = &chatConversation{Conversation: Conversation{
+			ConversationID:       strconv.FormatInt(id, 10),
+			ShopID:               789012,
+			LastMessageTimestamp: stamp,
+		}}
+	}
+
+	var got []string
+	var cursor conversationCursor
+	for page := 0; page < 10; page++ {
+		list, more := s.list(789012, "all", "older", cursor, 2)
+		for _, conv := range list {
+			got = append(got, conv.ConversationID)
+		}
+		if !more {
+			break
+		}
+		last := list[len(list)-1]
+		cursor.timestamp = last.LastMessageTimestamp
+		cursor.conversationID, _ = strconv.ParseInt(last.ConversationID, 10, 64)
+	}
+
+	want := []string{"1000000004", "1000000003", "1000000002", "1000000001", "1000000005"}
+	if len(got) != len(want) {
+		t.Fatalf("paged %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("paged %v, want %v", got, want)
+		}
+	}
+
+	latest, _ := s.list(789012, "all", "latest", conversationCursor{timestamp: timestamp, conversationID: 1000000002}, 10)
+	if len(latest) != 2 || latest[0].ConversationID != "1000000004" || latest[1].ConversationID != "1000000003" {
+		t.Errorf("latest after 1000000002 = %v, want 1000000004 and 1000000003", latest)
+	}
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetAccessTokenRequest struct {
+	Code          string `json:"code" example:"6d4a6b7c8e9f0a1b2c3d4e5f6a7b8c9d"`
+	PartnerID     int64  `json:"partner_id" example:"123456"`
+	ShopID        int64  `json:"shop_id" example:"789012"`
+	MainAccountID int64  `json:"main_account_id" example:"0"`
+}
+
+type RefreshAccessTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"4b6f7c8d9e0a1b2c3d4e5f6a7b8c9d0e"`
+	PartnerID    int64  `json:"partner_id" example:"123456"`
+	ShopID       int64  `json:"shop_id" example:"789012"`
+	MerchantID   int64  `json:"merchant_id" example:"0"`
+}
+
+type AccessTokenResponse struct {
+	RequestID      string  `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error          string  `json:"error" example:""`
+	Message        string  `json:"message" example:""`
+	AccessToken    string  `json:"access_token" example:"5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d"`
+	RefreshToken   string  `json:"refresh_token" example:"4b6f7c8d9e0a1b2c3d4e5f6a7b8c9d0e"`
+	ExpireIn       int64   `json:"expire_in" example:"14400"`
+	ShopIDList     []int64 `json:"shop_id_list" example:"789012"`
+	MerchantIDList []int64 `json:"merchant_id_list" example:"1001"`
+}
+
+type IssueTokenRequest struct {
+	ShopID     int64 `json:"shop_id" example:"789012"`
+	MerchantID int64 `json:"merchant_id" example:"0"`
+}
+
+type GetShopInfoResponse struct {
+	RequestID  string `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error      string `json:"error" example:""`
+	Message    string `json:"message" example:""`
+	ShopName   string `json:"shop_name" example:"Mock Shop TH"`
+	Region     string `json:"region" example:"TH"`
+	Status     string `json:"status" example:"NORMAL"`
+	IsCB       bool   `json:"is_cb" example:"false"`
+	MerchantID int64  `json:"merchant_id" example:"1001"`
+}
+
+type MerchantShop struct {
+	ShopID int64 `json:"shop_id" example:"789012"`
+}
+
+type GetShopListByMerchantResponse struct {
+	RequestID string         `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string         `json:"error" example:""`
+	Message   string         `json:"message" example:""`
+	ShopList  []MerchantShop `json:"shop_list"`
+	More      bool           `json:"more" example:"false"`
+}
+
+type GlobalItemListResponse struct {
+	GlobalItemList []ItemDetail `json:"global_item_list"`
+}
+
+type GetGlobalItemInfoResponse struct {
+	Error     string                 `json:"error" example:""`
+	Message   string                 `json:"message" example:""`
+	Warning   string                 `json:"warning" example:""`
+	RequestID string                 `json:"request_id" example:"7b9da0c6926642199c33ee9dd3a266f5"`
+	Response  GlobalItemListResponse `json:"response"`
+}
+
+func tokenResponse(token *accessToken) AccessTokenResponse {
+	return AccessTokenResponse{
+		RequestID:      newRequestID(),
+		AccessToken:    token.token,
+		RefreshToken:   token.refreshToken,
+		ExpireIn:       int64(accessTokenTTL.Seconds()),
+		ShopIDList:     append([]int64{}, token.shopIDList...),
+		MerchantIDList: append([]int64{}, token.merchantIDList...),
+	}
+}
+
+func tokenError(c *fiber.Ctx, err error) error {
+	code := "error_param"
+	if errors.Is(err, errInvalidRefresh) {
+		code = "error_auth"
+	}
+	return c.Status(400).JSON(AccessTokenResponse{
+		RequestID: newRequestID(),
+		Error:     code,
+		Message:   err.Error(),
+	})
+}
+
+// getAccessToken exchanges an authorization code for tokens
+// @Summary Get access token
+// @Description Exchanges the code from shop authorization for an access token. Any non-empty code is accepted. A main_account_id gives a merchant token that acts for every shop the merchant owns.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body GetAccessTokenRequest true "Authorization code and shop or main account"
+// @Success 200 {object} AccessTokenResponse "Tokens"
+// @Failure 400 {object} AccessTokenResponse "Bad request"
+// @Router /api/v2/auth/token/get [post]
+func getAccessToken(c *fiber.Ctx) error {
+	var req GetAccessTokenRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return tokenError(c, errors.New("Invalid request body"))
+	}
+	if req.Code == "" {
+		return tokenError(c, errors.New("code is required"))
+	}
+
+	token, err := tenants.issue(req.ShopID, req.MainAccountID, clock.Now())
+	if err != nil {
+		return tokenError(c, err)
+	}
+	return c.JSON(tokenResponse(token))
+}
+
+// refreshAccessToken swaps a refresh token for new tokens
+// @Summary Refresh access token
+// @Description Swaps a refresh token for a new access token with the same shops and merchants. Both old tokens stop working.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body RefreshAccessTokenRequest true "Refresh token"
+// @Success 200 {object} AccessTokenResponse "Tokens"
+// @Failure 400 {object} AccessTokenResponse "Bad request"
+// @Router /api/v2/auth/access_token/get [post]
+func refreshAccessToken(c *fiber.Ctx) error {
+	var req RefreshAccessTokenRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return tokenError(c, errors.New("Invalid request body"))
+	}
+
+	token, err := tenants.refresh(req.RefreshToken, clock.Now())
+	if err != nil {
+		return tokenError(c, err)
+	}
+	return c.JSON(tokenResponse(token))
+}
+
+// getShopInfo returns the calling shop's profile
+// @Summary Get shop info
+// @Description Returns the name, region and status of the shop
+// @Tags Shop
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 true "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Success 200 {object} GetShopInfoResponse "Success response"
+// @Failure 403 {object} map[string]interface{} "Unknown shop or token without access"
+// @Router /api/v2/shop/get_shop_info [get]
+func getShopInfo(c *fiber.Ctx) error {
+	shop := currentShop(c)
+	return c.JSON(GetShopInfoResponse{
+		RequestID:  newRequestID(),
+		ShopName:   shop.ShopName,
+		Region:     shop.Region,
+		Status:     shop.Status,
+		MerchantID: shop.MerchantID,
+	})
+}
+
+// getShopListByMerchant lists a merchant's shops
+// @Summary Get shop list by merchant
+// @Description Lists the shops owned by the merchant
+// @Tags Merchant
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param merchant_id query int64 true "Merchant ID" example(1001)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Success 200 {object} GetShopListByMerchantResponse "Success response"
+// @Failure 403 {object} map[string]interface{} "Unknown merchant or token without access"
+// @Router /api/v2/merchant/get_shop_list_by_merchant [get]
+func getShopListByMerchant(c *fiber.Ctx) error {
+	shops := []MerchantShop{}
+	for _, shopID := range currentMerchant(c).ShopIDList {
+		shops = append(shops, MerchantShop{ShopID: shopID})
+	}
+	return c.JSON(GetShopListByMerchantResponse{
+		RequestID: newRequestID(),
+		ShopList:  shops,
+	})
+}
+
+// addGlobalItem creates a merchant-level global item
+// @Summary Add global item
+// @Description Creates a global item owned by the merchant, validated against the catalogue like add_item
+// @Tags GlobalProduct
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param merchant_id query int64 true "Merchant ID" example(1001)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body AddItemRequest true "Global item to create"
+// @Success 200 {object} ItemWriteResponse "Success response; item_id is the global item ID"
+// @Failure 400 {object} ItemWriteResponse "Bad request"
+// @Failure 403 {object} map[string]interface{} "Unknown merchant or token without access"
+// @Router /api/v2/global_product/add_global_item [post]
+func addGlobalItem(c *fiber.Ctx) error {
+	var req AddItemRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ItemName == "" || req.OriginalPrice <= 0 {
+		return c.Status(400).JSON(ItemWriteResponse{
+			Error:   "error_param",
+			Message: "item_name and a positive original_price are required",
+		})
+	}
+
+	merchant := currentMerchant(c)
+	item, err := newItemFromRequest(req, merchant.Currency)
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(ItemWriteResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	item = store.addGlobalItem(merchant.MerchantID, item)
+	return c.JSON(ItemWriteResponse{
+		RequestID: newRequestID(),
+		Response:  &item,
+	})
+}
+
+// getGlobalItemInfo retrieves a merchant's global items
+// @Summary Get global item info
+// @Description Retrieves global items owned by the merchant. Unknown IDs and other merchants' items are skipped.
+// @Tags GlobalProduct
+// @Produce json
+// @Param global_item_id_list query string true "Array of global item IDs" example("[100001]")
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param merchant_id query int64 true "Merchant ID" example(1001)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Success 200 {object} GetGlobalItemInfoResponse "Success response; item_id is the global item ID"
+// @Failure 400 {object} GetGlobalItemInfoResponse "Bad request"
+// @Failure 403 {object} map[string]interface{} "Unknown merchant or token without access"
+// @Router /api/v2/global_product/get_global_item_info [get]
+func getGlobalItemInfo(c *fiber.Ctx) error {
+	ids, err := parseIDList(c.Query("global_item_id_list"))
+	if err != nil || len(ids) == 0 {
+		return c.Status(400).JSON(GetGlobalItemInfoResponse{
+			RequestID: newRequestID(),
+			Error:     "error_param",
+			Message:   "global_item_id_list must be a list of integers",
+		})
+	}
+
+	merchantID := currentMerchant(c).MerchantID
+	items := []ItemDetail{}
+	for _, id := range ids {
+		if item, ok := store.getGlobalItem(merchantID, id); ok {
+			items = append(items, item)
+		}
+	}
+	return c.JSON(GetGlobalItemInfoResponse{
+		RequestID: newRequestID(),
+		Response:  GlobalItemListResponse{GlobalItemList: items},
+	})
+}
+
+func tenantAdminError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errShopNotFound) || errors.Is(err, errMerchantNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminListShops lists the shops
+// @Summary List shops
+// @Description Lists every shop with its region, currency and merchant
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ShopListResponse "Shops"
+// @Router /admin/shops [get]
+func adminListShops(c *fiber.Ctx) error {
+	return c.JSON(ShopListResponse{ShopList: tenants.shopList()})
+}
+
+// adminAddShop adds a shop
+// @Summary Add shop
+// @Description Adds a shop, optionally owned by an existing merchant. Its orders, items and responses are kept apart from every other shop's and follow its region's profile; currency defaults to the region's.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body Shop true "Shop to add"
+// @Success 200 {object} Shop "Added shop"
+// @Failure 400 {object} map[string]interface{} "Invalid shop"
+// @Failure 404 {object} map[string]interface{} "Unknown merchant"
+// @Router /admin/shops [post]
+func adminAddShop(c *fiber.Ctx) error {
+	var shop Shop
+
+	if err := c.BodyParser(&shop); err != nil {
+		return tenantAdminError(c, errors.New("Invalid request body"))
+	}
+	if shop.ShopID <= 0 || shop.Region == "" {
+		return tenantAdminError(c, errors.New("shop_id and region are required"))
+	}
+
+	shop, err := tenants.addShop(shop)
+	if err != nil {
+		return tenantAdminError(c, err)
+	}
+	return c.JSON(shop)
+}
+
+// adminListMerchants lists the merchants
+// @Summary List merchants
+// @Description Lists every merchant with the shops it owns
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} MerchantListResponse "Merchants"
+// @Router /admin/merchants [get]
+func adminListMerchants(c *fiber.Ctx) error {
+	return c.JSON(MerchantListResponse{MerchantList: tenants.merchantList()})
+}
+
+// adminAddMerchant adds a merchant
+// @Summary Add merchant
+// @Description Adds a merchant account. Shops join it by naming its merchant_id when they are added.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body Merchant true "Merchant to add"
+// @Success 200 {object} Merchant "Added merchant"
+// @Failure 400 {object} map[string]interface{} "Invalid merchant"
+// @Router /admin/merchants [post]
+func adminAddMerchant(c *fiber.Ctx) error {
+	var merchant Merchant
+
+	if err := c.BodyParser(&merchant); err != nil {
+		return tenantAdminError(c, errors.New("Invalid request body"))
+	}
+	if merchant.MerchantID <= 0 || merchant.Currency == "" {
+		return tenantAdminError(c, errors.New("merchant_id and currency are required"))
+	}
+
+	merchant, err := tenants.addMerchant(merchant)
+	if err != nil {
+		return tenantAdminError(c, err)
+	}
+	return c.JSON(merchant)
+}
+
+// adminIssueToken issues an access token without the authorization flow
+// @Summary Issue access token
+// @Description Issues tokens for a shop, or for a merchant and all its shops, as if the seller had authorized the app
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body IssueTokenRequest true "Shop or merchant"
+// @Success 200 {object} AccessTokenResponse "Tokens"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Unknown shop or merchant"
+// @Router /admin/tokens [post]
+func adminIssueToken(c *fiber.Ctx) error {
+	var req IssueTokenRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return tenantAdminError(c, errors.New("Invalid request body"))
+	}
+
+	token, err := tenants.issue(req.ShopID, req.MerchantID, clock.Now())
+	if err != nil {
+		return tenantAdminError(c, err)
+	}
+	return c.JSON(tokenResponse(token))
+}
@@ -0,0 +1,579 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	errOrderNotFound     = errors.New("order not found")
+	errItemNotFound      = errors.New("item not found")
+	errOrderExists       = errors.New("order already exists")
+	errInsufficientStock = errors.New("insufficient stock")
+	errInvalidStatus     = errors.New("order status does not allow this operation")
+	errUnknownStatus     = errors.New("unknown order status")
+	errNoInvoice         = errors.New("buyer did not request an invoice for this order")
+	errNoInvoiceDoc      = errors.New("no invoice document has been uploaded for this order")
+)
+
+// defaultShopID is the shop used for requests that name none.
+const defaultShopID int64 = 789012
+
+// sampleOrderSN is the canned order the default shop starts with.
+const sampleOrderSN = "2404098R48U37H"
+
+// orderStatuses are the order statuses the mock knows, mapped to the stock
+// move an order created in that status makes: orders that have not shipped
+// hold their units in reserve, shipped ones have had them deducted and
+// cancelled ones hold none.
+var orderStatuses = map[string]string{
+	"UNPAID":             "ORDER_RESERVED",
+	"PENDING":            "ORDER_RESERVED",
+	"READY_TO_SHIP":      "ORDER_RESERVED",
+	"IN_CANCEL":          "ORDER_RESERVED",
+	"PROCESSED":          "ORDER_SHIPPED",
+	"SHIPPED":            "ORDER_SHIPPED",
+	"TO_CONFIRM_RECEIVE": "ORDER_SHIPPED",
+	"COMPLETED":          "ORDER_SHIPPED",
+	"TO_RETURN":          "ORDER_SHIPPED",
+	"CANCELLED":          "",
+}
+
+// orderAutoCompleteAfter is how long a shipped order waits for the buyer to
+// confirm receipt before it completes on its own.
+var orderAutoCompleteAfter = time.Duration(getEnvInt("ORDER_AUTO_COMPLETE_DAYS", 7)) * 24 * time.Hour
+
+// mockStore holds the orders and items served by the mock. Orders and items
+// share one lock so that stock moves atomically with order status changes.
+// Every order and item belongs to one shop and is invisible to the others;
+// global items belong to a merchant.
+type mockStore struct {
+	mu                 sync.Mutex
+	orders             map[string]*OrderDetail
+	orderShops         map[string]int64
+	items              map[int64]*ItemDetail
+	itemShops          map[int64]int64
+	nextItemID         int64
+	globalItems        map[int64]*ItemDetail
+	globalItemMerchant map[int64]int64
+	nextGlobalItemID   int64
+	invoices           map[string]*BuyerInvoice
+	invoiceDocs        map[string]*invoiceDoc
+}
+
+var store = newMockStore()
+
+func newMockStore() *mockStore {
+	s := &mockStore{
+		orders:             make(map[string]*OrderDetail),
+		orderShops:         make(map[string]int64),
+		items:              make(map[int64]*ItemDetail),
+		itemShops:          make(map[int64]int64),
+		nextItemID:         34003,
+		globalItems:        make(map[int64]*ItemDetail),
+		globalItemMerchant: make(map[int64]int64),
+		nextGlobalItemID:   100001,
+		invoices:           make(map[string]*BuyerInvoice),
+		invoiceDocs:        make(map[string]*invoiceDoc),
+	}
+	for _, itemID := range []int64{34001, 34002} {
+		item := newMockItem(itemID)
+		localizeItem(&item, "THB")
+		s.items[itemID] = &item
+		s.itemShops[itemID] = defaultShopID
+	}
+	order := newMockOrder(sampleOrderSN)
+	s.orders[sampleOrderSN] = &order
+	s.orderShops[sampleOrderSN] = defaultShopID
+	return s
+}
+
+// order returns a shop's order. Callers must hold s.mu.
+func (s *mockStore) order(shopID int64, orderSN string) (*OrderDetail, bool) {
+	order, ok := s.orders[orderSN]
+	if !ok || s.orderShops[orderSN] != shopID {
+		return nil, false
+	}
+	return order, true
+}
+
+// item returns a shop's item. Callers must hold s.mu.
+func (s *mockStore) item(shopID, itemID int64) (*ItemDetail, bool) {
+	item, ok := s.items[itemID]
+	if !ok || s.itemShops[itemID] != shopID {
+		return nil, false
+	}
+	return item, true
+}
+
+func (s *mockStore) getOrder(shopID int64, orderSN string) (OrderDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, false
+	}
+	return *order, true
+}
+
+// buyerUsername returns the username of a buyer who has ordered from a
+// shop.
+func (s *mockStore) buyerUsername(shopID, buyerUserID int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for orderSN, order := range s.orders {
+		if s.orderShops[orderSN] == shopID && order.BuyerUserID == buyerUserID {
+			return order.BuyerUsername, true
+		}
+	}
+	return "", false
+}
+
+func (s *mockStore) getItem(shopID, itemID int64) (ItemDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.item(shopID, itemID)
+	if !ok {
+		return ItemDetail{}, false
+	}
+	return *item, true
+}
+
+// addItem stores a new item for a shop under the next free item ID.
+func (s *mockStore) addItem(shopID int64, item ItemDetail) ItemDetail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item.ItemID = s.nextItemID
+	s.nextItemID++
+	s.items[item.ItemID] = &item
+	s.itemShops[item.ItemID] = shopID
+	return item
+}
+
+// reserveItemIDs sets aside n consecutive item IDs and returns the first.
+func (s *mockStore) reserveItemIDs(n int) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	first := s.nextItemID
+	s.nextItemID += int64(n)
+	return first
+}
+
+// shopItems returns a shop's items ordered by ID.
+func (s *mockStore) shopItems(shopID int64) []ItemDetail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []ItemDetail
+	for itemID, owner := range s.itemShops {
+		if owner == shopID {
+			items = append(items, *s.items[itemID])
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ItemID < items[j].ItemID })
+	return items
+}
+
+// importData stores generated items and orders for a shop as they are,
+// without moving stock or pushing status changes. Orders whose SN is taken
+// are skipped; the number imported is returned.
+func (s *mockStore) importData(shopID int64, items []ItemDetail, orders []OrderDetail) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range items {
+		item := items[i]
+		s.items[item.ItemID] = &item
+		s.itemShops[item.ItemID] = shopID
+	}
+	imported := 0
+	for i := range orders {
+		order := orders[i]
+		if _, ok := s.orders[order.OrderSN]; ok {
+			continue
+		}
+		s.orders[order.OrderSN] = &order
+		s.orderShops[order.OrderSN] = shopID
+		imported++
+	}
+	return imported
+}
+
+// addGlobalItem stores a new global item for a merchant under the next free
+// global item ID.
+func (s *mockStore) addGlobalItem(merchantID int64, item ItemDetail) ItemDetail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item.ItemID = s.nextGlobalItemID
+	s.nextGlobalItemID++
+	s.globalItems[item.ItemID] = &item
+	s.globalItemMerchant[item.ItemID] = merchantID
+	return item
+}
+
+func (s *mockStore) getGlobalItem(merchantID, globalItemID int64) (ItemDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.globalItems[globalItemID]
+	if !ok || s.globalItemMerchant[globalItemID] != merchantID {
+		return ItemDetail{}, false
+	}
+	return *item, true
+}
+
+// updateItem applies update to a copy of a shop's item and stores the
+// result if update succeeds.
+func (s *mockStore) updateItem(shopID, itemID int64, update func(*ItemDetail) error) (ItemDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.item(shopID, itemID)
+	if !ok {
+		return ItemDetail{}, errItemNotFound
+	}
+
+	updated := *item
+	if err := update(&updated); err != nil {
+		return ItemDetail{}, err
+	}
+	s.items[itemID] = &updated
+	return updated, nil
+}
+
+// createOrder stores a new order for a shop and moves stock for every line
+// item that refers to one of the shop's items: reserved for orders that
+// have not shipped, deducted for those created already shipped, untouched
+// for cancelled ones. Nothing moves if any line is short of stock. Order
+// SNs are unique across shops.
+func (s *mockStore) createOrder(shopID int64, order OrderDetail) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	action, ok := orderStatuses[order.OrderStatus]
+	if !ok {
+		return OrderDetail{}, errUnknownStatus
+	}
+	if _, ok := s.orders[order.OrderSN]; ok {
+		return OrderDetail{}, errOrderExists
+	}
+
+	if action != "" {
+		for itemID, quantity := range orderQuantities(order) {
+			item, ok := s.item(shopID, itemID)
+			if !ok {
+				continue
+			}
+			if item.StockInfoV2.SummaryInfo.TotalAvailableStock < quantity {
+				return OrderDetail{}, errInsufficientStock
+			}
+		}
+	}
+	for itemID, quantity := range orderQuantities(order) {
+		switch action {
+		case "ORDER_RESERVED":
+			s.moveStock(shopID, itemID, -quantity, quantity, action)
+		case "ORDER_SHIPPED":
+			s.moveStock(shopID, itemID, -quantity, 0, action)
+		}
+	}
+
+	s.orders[order.OrderSN] = &order
+	s.orderShops[order.OrderSN] = shopID
+	pushOrderStatusChange(shopID, order)
+	return order, nil
+}
+
+// getInvoice returns the invoice the buyer requested for a shop's order.
+func (s *mockStore) getInvoice(shopID int64, orderSN string) (BuyerInvoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.order(shopID, orderSN); !ok {
+		return BuyerInvoice{}, errOrderNotFound
+	}
+	invoice, ok := s.invoices[orderSN]
+	if !ok {
+		return BuyerInvoice{}, errNoInvoice
+	}
+	return *invoice, nil
+}
+
+// setInvoice records the invoice a buyer requested for an order, or with
+// a nil invoice withdraws the request along with any uploaded document.
+func (s *mockStore) setInvoice(orderSN string, invoice *BuyerInvoice) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderSN]
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+	if invoice == nil {
+		delete(s.invoices, orderSN)
+		delete(s.invoiceDocs, orderSN)
+		return *order, nil
+	}
+	s.invoices[orderSN] = invoice
+	return *order, nil
+}
+
+// getInvoiceDoc returns the invoice document the seller uploaded for a
+// shop's order.
+func (s *mockStore) getInvoiceDoc(shopID int64, orderSN string) (invoiceDoc, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.order(shopID, orderSN); !ok {
+		return invoiceDoc{}, errOrderNotFound
+	}
+	doc, ok := s.invoiceDocs[orderSN]
+	if !ok {
+		return invoiceDoc{}, errNoInvoiceDoc
+	}
+	return *doc, nil
+}
+
+// setInvoiceDoc records the invoice document a seller uploaded for a
+// shop's order, replacing any earlier one. The buyer must have requested
+// an invoice.
+func (s *mockStore) setInvoiceDoc(shopID int64, orderSN string, doc invoiceDoc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.order(shopID, orderSN); !ok {
+		return errOrderNotFound
+	}
+	if _, ok := s.invoices[orderSN]; !ok {
+		return errNoInvoice
+	}
+	s.invoiceDocs[orderSN] = &doc
+	return nil
+}
+
+// cancelOrder cancels an order that has not shipped yet and releases its
+// reserved stock back to available.
+func (s *mockStore) cancelOrder(shopID int64, orderSN, cancelBy, reason string, now time.Time) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+
+	switch order.OrderStatus {
+	case "UNPAID", "PENDING", "READY_TO_SHIP", "IN_CANCEL":
+	default:
+		return OrderDetail{}, errInvalidStatus
+	}
+
+	for itemID, quantity := range orderQuantities(*order) {
+		s.moveStock(shopID, itemID, quantity, -quantity, "ORDER_CANCELLED")
+	}
+
+	order.OrderStatus = "CANCELLED"
+	order.CancelBy = cancelBy
+	order.CancelReason = reason
+	order.UpdateTime = now.Unix()
+	pushOrderStatusChange(shopID, *order)
+	return *order, nil
+}
+
+// shipOrder arranges shipment for an order under the given tracking number
+// and deducts its reserved stock for good.
+func (s *mockStore) shipOrder(shopID int64, orderSN, trackingNo string, now time.Time) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+	if order.OrderStatus != "READY_TO_SHIP" {
+		return OrderDetail{}, errInvalidStatus
+	}
+
+	for itemID, quantity := range orderQuantities(*order) {
+		s.moveStock(shopID, itemID, 0, -quantity, "ORDER_SHIPPED")
+	}
+
+	order.OrderStatus = "PROCESSED"
+	order.UpdateTime = now.Unix()
+	pushOrderStatusChange(shopID, *order)
+	for _, pkg := range order.PackageList {
+		webhooks.push(pushTrackingNo, shopID, TrackingNoPush{
+			OrderSN:       order.OrderSN,
+			ForderID:      randomDigits(19),
+			PackageNumber: pkg.PackageNumber,
+			TrackingNo:    trackingNo,
+		})
+	}
+
+	clock.schedule(now.Add(orderAutoCompleteAfter), func(at time.Time) {
+		s.completeOrder(shopID, orderSN, at)
+	})
+	return *order, nil
+}
+
+// payOrder records payment for an unpaid order, or verifies the payment of a
+// pending one, making it ready to ship.
+func (s *mockStore) payOrder(shopID int64, orderSN string, now time.Time) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+	if order.OrderStatus != "UNPAID" && order.OrderStatus != "PENDING" {
+		return OrderDetail{}, errInvalidStatus
+	}
+
+	order.OrderStatus = "READY_TO_SHIP"
+	order.PayTime = now.Unix()
+	order.ShipByDate = now.AddDate(0, 0, order.DaysToShip).Unix()
+	order.UpdateTime = now.Unix()
+	pushOrderStatusChange(shopID, *order)
+	return *order, nil
+}
+
+// setNote replaces the seller's note on an order.
+func (s *mockStore) setNote(shopID int64, orderSN, note string, now time.Time) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+
+	order.Note = note
+	order.NoteUpdateTime = now.Unix()
+	return *order, nil
+}
+
+// setOrderStatus moves an order to another known status, for the buyer and
+// logistics events the mock has no API for. Stock follows the move as it
+// does through the other transitions: an order leaving READY_TO_SHIP for a
+// shipped status has its reserved stock deducted and its auto-complete
+// timer started, and a cancelled one has its stock released. Orders cannot
+// be shipped before they are ready, cancelled once shipped or moved on once
+// cancelled.
+func (s *mockStore) setOrderStatus(shopID int64, orderSN, status string, now time.Time) (OrderDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return OrderDetail{}, errOrderNotFound
+	}
+	to, ok := orderStatuses[status]
+	if !ok {
+		return OrderDetail{}, errUnknownStatus
+	}
+
+	switch from := orderStatuses[order.OrderStatus]; {
+	case from == "ORDER_RESERVED" && to == "ORDER_SHIPPED":
+		if order.OrderStatus != "READY_TO_SHIP" {
+			return OrderDetail{}, errInvalidStatus
+		}
+		for itemID, quantity := range orderQuantities(*order) {
+			s.moveStock(shopID, itemID, 0, -quantity, "ORDER_SHIPPED")
+		}
+		if status != "COMPLETED" && status != "TO_RETURN" {
+			clock.schedule(now.Add(orderAutoCompleteAfter), func(at time.Time) {
+				s.completeOrder(shopID, orderSN, at)
+			})
+		}
+	case from == "ORDER_RESERVED" && to == "":
+		for itemID, quantity := range orderQuantities(*order) {
+			s.moveStock(shopID, itemID, quantity, -quantity, "ORDER_CANCELLED")
+		}
+	case from == "", from != to:
+		return OrderDetail{}, errInvalidStatus
+	}
+
+	order.OrderStatus = status
+	if status == "SHIPPED" && order.PickupDoneTime == 0 {
+		order.PickupDoneTime = now.Unix()
+	}
+	order.UpdateTime = now.Unix()
+	pushOrderStatusChange(shopID, *order)
+	return *order, nil
+}
+
+// completeOrder completes a shipped order once its auto-complete timer is
+// due. Orders that have since moved on are left alone.
+func (s *mockStore) completeOrder(shopID int64, orderSN string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.order(shopID, orderSN)
+	if !ok {
+		return
+	}
+	switch order.OrderStatus {
+	case "PROCESSED", "SHIPPED", "TO_CONFIRM_RECEIVE":
+	default:
+		return
+	}
+
+	order.OrderStatus = "COMPLETED"
+	order.UpdateTime = at.Unix()
+	pushOrderStatusChange(shopID, *order)
+}
+
+// moveStock adjusts the available and reserved stock of an item and pushes
+// the reserved stock change, if there is one. Unknown items are ignored so
+// orders may reference products the store does not track. Callers must
+// hold s.mu.
+func (s *mockStore) moveStock(shopID, itemID int64, available, reserved int, action string) {
+	item, ok := s.item(shopID, itemID)
+	if !ok {
+		return
+	}
+	oldReserved := item.StockInfoV2.SummaryInfo.TotalReservedStock
+	item.StockInfoV2.SummaryInfo.TotalAvailableStock += available
+	item.StockInfoV2.SummaryInfo.TotalReservedStock += reserved
+	item.UpdateTime = clock.Now().Unix()
+	if reserved != 0 {
+		pushReservedStockChange(shopID, *item, action, oldReserved)
+	}
+}
+
+// orderQuantities sums the purchased quantity per item across an order's
+// line items.
+func orderQuantities(order OrderDetail) map[int64]int {
+	quantities := make(map[int64]int)
+	for _, orderItem := range order.ItemList {
+		quantities[orderItem.ItemID] += orderItem.ModelQuantityPurchased
+	}
+	return quantities
+}
+
+// storeError maps a store error to the HTTP status and Shopee error code
+// returned to the caller.
+func storeError(err error) (int, string) {
+	switch {
+	case errors.Is(err, errOrderNotFound), errors.Is(err, errItemNotFound), errors.Is(err, errShopNotFound),
+		errors.Is(err, errImageNotFound), errors.Is(err, errVideoUploadNotFound), errors.Is(err, errNoInvoiceDoc),
+		errors.Is(err, errConversationNotFound), errors.Is(err, errBuyerNotFound), errors.Is(err, errDiscountNotFound),
+		errors.Is(err, errVoucherNotFound):
+		return 404, "error_not_found"
+	case errors.Is(err, errOrderExists), errors.Is(err, errInvalidItem), errors.Is(err, errVideoNotReady),
+		errors.Is(err, errInvalidChatMessage), errors.Is(err, errInvalidDiscount), errors.Is(err, errDiscountExpired),
+		errors.Is(err, errInvalidVoucher), errors.Is(err, errUnknownStatus):
+		return 400, "error_param"
+	default:
+		return 400, "error_busi"
+	}
+}
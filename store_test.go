@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderStockLifecycle(t *testing.T) {
+	const itemID = 34001
+	s := newMockStore()
+	now := clock.Now()
+
+	stock := func() (available, reserved int) {
+		item, _ := s.getItem(defaultShopID, itemID)
+		return item.StockInfoV2.SummaryInfo.TotalAvailableStock, item.StockInfoV2.SummaryInfo.TotalReservedStock
+	}
+	create := func(orderSN, status string) error {
+		_, err := s.createOrder(defaultShopID, OrderDetail{
+			OrderSN:     orderSN,
+			OrderStatus: status,
+			ItemList:    []OrderItem{{ItemID: itemID, ModelQuantityPurchased: 2}},
+		})
+		return err
+	}
+	check := func(step string, wantAvailable, wantReserved int) {
+		t.Helper()
+		if available, reserved := stock(); available != wantAvailable || reserved != wantReserved {
+			t.Errorf("%s: available %d, reserved %d, want %d, %d", step, available, reserved, wantAvailable, wantReserved)
+		}
+	}
+	available, reserved := stock()
+
+	if err := create("RESERVE", "READY_TO_SHIP"); err != nil {
+		t.Fatal(err)
+	}
+	check("reserve", available-2, reserved+2)
+	if _, err := s.cancelOrder(defaultShopID, "RESERVE", "seller", "Out of Stock", now); err != nil {
+		t.Fatal(err)
+	}
+	check("cancel", available, reserved)
+
+	if err := create("SHIP", "UNPAID"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.payOrder(defaultShopID, "SHIP", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.shipOrder(defaultShopID, "SHIP", "TH123", now); err != nil {
+		t.Fatal(err)
+	}
+	check("ship", available-2, reserved)
+
+	if err := create("COMPLETED", "COMPLETED"); err != nil {
+		t.Fatal(err)
+	}
+	check("created completed", available-4, reserved)
+	if err := create("CANCELLED", "CANCELLED"); err != nil {
+		t.Fatal(err)
+	}
+	check("created cancelled", available-4, reserved)
+
+	if err := create("UNKNOWN", "DONE"); !errors.Is(err, errUnknownStatus) {
+		t.Errorf("unknown status: err = %v, want %v", err, errUnknownStatus)
+	}
+	check("unknown status", available-4, reserved)
+}
+
+func TestSetOrderStatusMovesStock(t *testing.T) {
+	const itemID = 34002
+	s := newMockStore()
+	now := clock.Now()
+
+	reserved := func() int {
+		item, _ := s.getItem(defaultShopID, itemID)
+		return item.StockInfoV2.SummaryInfo.TotalReservedStock
+	}
+	before := reserved()
+	for _, orderSN := range []string{"SHIPPED", "COMPLETED", "CANCELLED", "UNPAID"} {
+		status := "READY_TO_SHIP"
+		if orderSN == "UNPAID" {
+			status = orderSN
+		}
+		if _, err := s.createOrder(defaultShopID, OrderDetail{
+			OrderSN:     orderSN,
+			OrderStatus: status,
+			ItemList:    []OrderItem{{ItemID: itemID, ModelQuantityPurchased: 1}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := reserved(); got != before+4 {
+		t.Fatalf("reserved %d, want %d", got, before+4)
+	}
+
+	timers := clock.state().PendingTimers
+	tests := []struct {
+		orderSN, status string
+		want            error
+		wantReserved    int
+	}{
+		{"SHIPPED", "SHIPPED", nil, before + 3},
+		{"COMPLETED", "COMPLETED", nil, before + 2},
+		{"CANCELLED", "CANCELLED", nil, before + 1},
+		{"UNPAID", "SHIPPED", errInvalidStatus, before + 1},
+		{"UNPAID", "DONE", errUnknownStatus, before + 1},
+		{"SHIPPED", "READY_TO_SHIP", errInvalidStatus, before + 1},
+		{"SHIPPED", "CANCELLED", errInvalidStatus, before + 1},
+		{"CANCELLED", "READY_TO_SHIP", errInvalidStatus, before + 1},
+		{"SHIPPED", "TO_CONFIRM_RECEIVE", nil, before + 1},
+	}
+	for _, tt := range tests {
+		if _, err := s.setOrderStatus(defaultShopID, tt.orderSN, tt.status, now); !errors.Is(err, tt.want) {
+			t.Errorf("%s to %s: err = %v, want %v", tt.orderSN, tt.status, err, tt.want)
+		}
+		if got := reserved(); got != tt.wantReserved {
+			t.Errorf("%s to %s: reserved %d, want %d", tt.orderSN, tt.status, got, tt.wantReserved)
+		}
+	}
+	if got := clock.state().PendingTimers; got != timers+1 {
+		t.Errorf("%d auto-complete timers scheduled, want 1", got-timers)
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errStubNotFound = errors.New("stub rule not found")
+
+// StubRule answers matching API requests with a canned response instead of
+// the built-in handler. Path may end in "*" to match a prefix. Query and
+// Headers must match exactly; Body maps dotted JSON paths to values, where
+// a condition on an array holds if any element matches it. Rules with a
+// higher priority are tried first, then older rules before newer ones.
+type StubRule struct {
+	ID           int64             `json:"id" example:"1"`
+	Priority     int               `json:"priority" example:"10"`
+	Method       string            `json:"method" example:"POST"`
+	Path         string            `json:"path" example:"/api/v2/order/get_buyer_invoice_info"`
+	Query        map[string]string `json:"query,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Body         map[string]string `json:"body,omitempty"`
+	Status       int               `json:"status" example:"200"`
+	ContentType  string            `json:"content_type" example:"application/json"`
+	ResponseBody json.RawMessage   `json:"response_body,omitempty" swaggertype:"object"`
+	ResponseText string            `json:"response_text,omitempty" example:""`
+	HitCount     int               `json:"hit_count" example:"0"`
+}
+
+type StubRuleListResponse struct {
+	RuleList []StubRule `json:"rule_list"`
+}
+
+func (r StubRule) validate() error {
+	if r.Path == "" {
+		return errors.New("path is required")
+	}
+	if r.Status != 0 && (r.Status < 100 || r.Status > 599) {
+		return errors.New("status must be a valid HTTP status")
+	}
+	if r.ResponseBody != nil && r.ResponseText != "" {
+		return errors.New("only one of response_body and response_text may be set")
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to a request. body is the
+// decoded JSON body, or nil if the request has none.
+func (r StubRule) matches(c *fiber.Ctx, body interface{}) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, c.Method()) {
+		return false
+	}
+	if !matchPath(r.Path, c.Path()) {
+		return false
+	}
+	for name, value := range r.Query {
+		if c.Query(name) != value {
+			return false
+		}
+	}
+	for name, value := range r.Headers {
+		if c.Get(name) != value {
+			return false
+		}
+	}
+	for name, value := range r.Body {
+		if body == nil || !jsonFieldMatches(body, strings.Split(name, "."), value) {
+			return false
+		}
+	}
+	return true
+}
+
+type stubRegistry struct {
+	mu     sync.Mutex
+	rules  []StubRule
+	nextID int64
+}
+
+var stubs = &stubRegistry{nextID: 1}
+
+func (s *stubRegistry) list() []StubRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]StubRule{}, s.rules...)
+}
+
+func (s *stubRegistry) add(rule StubRule) StubRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule.ID = s.nextID
+	rule.HitCount = 0
+	s.nextID++
+	s.rules = append(s.rules, rule)
+	s.sort()
+	return rule
+}
+
+func (s *stubRegistry) replace(id int64, rule StubRule) (StubRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			rule.ID = id
+			rule.HitCount = s.rules[i].HitCount
+			s.rules[i] = rule
+			s.sort()
+			return rule, nil
+		}
+	}
+	return StubRule{}, errStubNotFound
+}
+
+func (s *stubRegistry) remove(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errStubNotFound
+}
+
+func (s *stubRegistry) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
+}
+
+// sort orders the rules by descending priority, keeping older rules first
+// among equals. Callers must hold s.mu.
+func (s *stubRegistry) sort() {
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		if s.rules[i].Priority != s.rules[j].Priority {
+			return s.rules[i].Priority > s.rules[j].Priority
+		}
+		return s.rules[i].ID < s.rules[j].ID
+	})
+}
+
+// match returns the first rule that applies to the request and counts the
+// hit.
+func (s *stubRegistry) match(c *fiber.Ctx) (StubRule, bool) {
+	var body interface{}
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.UseNumber()
+	if decoder.Decode(&body) != nil {
+		body = nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rules {
+		if s.rules[i].matches(c, body) {
+			s.rules[i].HitCount++
+			return s.rules[i], true
+		}
+	}
+	return StubRule{}, false
+}
+
+// stubResponses is a Fiber middleware that answers API requests matching a
+// stub rule before they reach the built-in handlers.
+func stubResponses(c *fiber.Ctx) error {
+	rule, ok := stubs.match(c)
+	if !ok {
+		return c.Next()
+	}
+
+	status := rule.Status
+	if status == 0 {
+		status = 200
+	}
+	contentType := rule.ContentType
+	if contentType == "" && rule.ResponseBody != nil {
+		contentType = fiber.MIMEApplicationJSON
+	}
+	if contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	}
+
+	c.Status(status)
+	if rule.ResponseBody != nil {
+		return c.Send(rule.ResponseBody)
+	}
+	return c.SendString(rule.ResponseText)
+}
+
+func stubRuleError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errStubNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminListStubs lists the stub rules
+// @Summary List stub rules
+// @Description Lists the stub rules in the order they are tried, with how often each has matched
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} StubRuleListResponse "Rules"
+// @Router /admin/stubs [get]
+func adminListStubs(c *fiber.Ctx) error {
+	return c.JSON(StubRuleListResponse{RuleList: stubs.list()})
+}
+
+// adminAddStub adds a stub rule
+// @Summary Add stub rule
+// @Description Adds a rule that answers matching API requests with a canned status and body, ahead of the built-in handlers. A status of 0 is treated as 200.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body StubRule true "Rule to add"
+// @Success 200 {object} StubRule "Added rule"
+// @Failure 400 {object} map[string]interface{} "Invalid rule"
+// @Router /admin/stubs [post]
+func adminAddStub(c *fiber.Ctx) error {
+	var rule StubRule
+
+	if err := c.BodyParser(&rule); err != nil {
+		return stubRuleError(c, errors.New("Invalid request body"))
+	}
+	if err := rule.validate(); err != nil {
+		return stubRuleError(c, err)
+	}
+
+	return c.JSON(stubs.add(rule))
+}
+
+// adminUpdateStub replaces a stub rule
+// @Summary Update stub rule
+// @Description Replaces an existing stub rule, keeping its hit count
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Rule ID" example(1)
+// @Param request body StubRule true "New rule"
+// @Success 200 {object} StubRule "Updated rule"
+// @Failure 400 {object} map[string]interface{} "Invalid rule"
+// @Failure 404 {object} map[string]interface{} "Unknown rule"
+// @Router /admin/stubs/{id} [put]
+func adminUpdateStub(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return stubRuleError(c, errStubNotFound)
+	}
+
+	var rule StubRule
+	if err := c.BodyParser(&rule); err != nil {
+		return stubRuleError(c, errors.New("Invalid request body"))
+	}
+	if err := rule.validate(); err != nil {
+		return stubRuleError(c, err)
+	}
+
+	rule, err = stubs.replace(id, rule)
+	if err != nil {
+		return stubRuleError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// adminDeleteStub removes a stub rule
+// @Summary Delete stub rule
+// @Description Removes one stub rule
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Rule ID" example(1)
+// @Success 204 "Rule removed"
+// @Failure 404 {object} map[string]interface{} "Unknown rule"
+// @Router /admin/stubs/{id} [delete]
+func adminDeleteStub(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return stubRuleError(c, errStubNotFound)
+	}
+
+	if err := stubs.remove(id); err != nil {
+		return stubRuleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// adminClearStubs removes every stub rule
+// @Summary Clear stub rules
+// @Description Removes every stub rule
+// @Tags Admin
+// @Success 204 "Rules removed"
+// @Router /admin/stubs [delete]
+func adminClearStubs(c *fiber.Ctx) error {
+	stubs.clear()
+	return c.SendStatus(204)
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	errTaxIDFormat   = errors.New("invalid tax_id format")
+	errTaxIDChecksum = errors.New("invalid tax_id check digit")
+)
+
+var (
+	vnTaxIDPattern = regexp.MustCompile(`^([0-9]{10}(-[0-9]{3})?|[0-9]{12})$`)
+	sgUENPattern   = regexp.MustCompile(`^([0-9]{8}[A-Z]|[0-9]{9}[A-Z]|[TSR][0-9]{2}[A-Z]{2}[0-9]{4}[A-Z])$`)
+	myTaxIDPattern = regexp.MustCompile(`^[A-Z]{1,2}[0-9]{9,11}$`)
+	phTaxIDPattern = regexp.MustCompile(`^[0-9]{3}-?[0-9]{3}-?[0-9]{3}(-?[0-9]{3}|-?[0-9]{5})?$`)
+	mxRFCPattern   = regexp.MustCompile(`^[A-ZÑ&]{3,4}[0-9]{6}[A-Z0-9]{3}$`)
+)
+
+// InvoiceSettings controls how buyer invoices are served.
+type InvoiceSettings struct {
+	InvalidTaxIDs bool `json:"invalid_tax_ids" example:"false"`
+}
+
+type invoiceSettingsStore struct {
+	mu       sync.Mutex
+	settings InvoiceSettings
+}
+
+var invoiceSettings = &invoiceSettingsStore{
+	settings: InvoiceSettings{InvalidTaxIDs: getEnv("INVALID_TAX_IDS", "") == "true"},
+}
+
+func (s *invoiceSettingsStore) get() InvoiceSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings
+}
+
+func (s *invoiceSettingsStore) set(settings InvoiceSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+// validateTaxID checks a tax ID against the format of a shop's region, and
+// its check digit where the region has one. Spaces, dots and, except in
+// Vietnam and the Philippines where they are part of the format, dashes
+// are ignored.
+func validateTaxID(region, taxID string) error {
+	id := strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "/", "").Replace(taxID))
+	if region != "VN" && region != "PH" {
+		id = strings.ReplaceAll(id, "-", "")
+	}
+
+	switch region {
+	case "TH":
+		return validateThaiTaxID(id)
+	case "VN":
+		return matchTaxID(vnTaxIDPattern, id)
+	case "SG":
+		if len(id) == 9 && strings.ContainsRune("STFG", rune(id[0])) {
+			return validateNRIC(id)
+		}
+		return matchTaxID(sgUENPattern, id)
+	case "MY":
+		return matchTaxID(myTaxIDPattern, id)
+	case "PH":
+		return matchTaxID(phTaxIDPattern, id)
+	case "ID":
+		if !isDigits(id) || (len(id) != 15 && len(id) != 16) {
+			return errTaxIDFormat
+		}
+		return nil
+	case "TW":
+		return validateTaiwanUBN(id)
+	case "BR":
+		if len(id) == 14 {
+			return validateCNPJ(id)
+		}
+		return validateCPF(id)
+	case "MX":
+		return matchTaxID(mxRFCPattern, id)
+	case "PL":
+		return validateNIP(id)
+	}
+	return nil
+}
+
+func matchTaxID(pattern *regexp.Regexp, id string) error {
+	if !pattern.MatchString(id) {
+		return errTaxIDFormat
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// weightedSum multiplies each digit of s by the matching weight.
+func weightedSum(s string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(s[i]-'0') * w
+	}
+	return sum
+}
+
+// validateThaiTaxID checks a 13 digit Thai national ID or TIN: the last
+// digit is (11 - sum mod 11) mod 10, weighting the first twelve from 13
+// down to 2.
+func validateThaiTaxID(id string) error {
+	if len(id) != 13 || !isDigits(id) {
+		return errTaxIDFormat
+	}
+	sum := weightedSum(id, []int{13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+	if (11-sum%11)%10 != int(id[12]-'0') {
+		return errTaxIDChecksum
+	}
+	return nil
+}
+
+// validateNRIC checks a Singapore NRIC or FIN check letter.
+func validateNRIC(id string) error {
+	if !isDigits(id[1:8]) || id[8] < 'A' || id[8] > 'Z' {
+		return errTaxIDFormat
+	}
+	sum := weightedSum(id[1:8], []int{2, 7, 6, 5, 4, 3, 2})
+	letters := "JZIHGFEDCBA"
+	if id[0] == 'F' || id[0] == 'G' {
+		letters = "XWUTRQPNMLK"
+	}
+	if id[0] == 'T' || id[0] == 'G' {
+		sum += 4
+	}
+	if letters[sum%11] != id[8] {
+		return errTaxIDChecksum
+	}
+	return nil
+}
+
+// validateTaiwanUBN checks an 8 digit unified business number. The digit
+// sums of the weighted digits must add up to a multiple of 5; when the
+// seventh digit is 7 its product 28 may count as either 1 or 0.
+func validateTaiwanUBN(id string) error {
+	if len(id) != 8 || !isDigits(id) {
+		return errTaxIDFormat
+	}
+	sum := 0
+	for i, w := range []int{1, 2, 1, 2, 1, 2, 4, 1} {
+		p := int(id[i]-'0') * w
+		sum += p/10 + p%10
+	}
+	if sum%5 == 0 || (id[6] == '7' && (sum+1)%5 == 0) {
+		return nil
+	}
+	return errTaxIDChecksum
+}
+
+// validateCPF checks the two check digits of an 11 digit Brazilian CPF.
+func validateCPF(id string) error {
+	if len(id) != 11 || !isDigits(id) {
+		return errTaxIDFormat
+	}
+	if strings.Count(id, id[:1]) == len(id) {
+		return errTaxIDChecksum
+	}
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(id[i]-'0') * (n + 1 - i)
+		}
+		if (sum*10%11)%10 != int(id[n]-'0') {
+			return errTaxIDChecksum
+		}
+	}
+	return nil
+}
+
+// validateCNPJ checks the two check digits of a 14 digit Brazilian CNPJ.
+func validateCNPJ(id string) error {
+	if !isDigits(id) {
+		return errTaxIDFormat
+	}
+	if strings.Count(id, id[:1]) == len(id) {
+		return errTaxIDChecksum
+	}
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for n := 12; n <= 13; n++ {
+		rest := weightedSum(id, weights[13-n:]) % 11
+		check := 0
+		if rest >= 2 {
+			check = 11 - rest
+		}
+		if check != int(id[n]-'0') {
+			return errTaxIDChecksum
+		}
+	}
+	return nil
+}
+
+// validateNIP checks a 10 digit Polish NIP.
+func validateNIP(id string) error {
+	id = strings.TrimPrefix(id, "PL")
+	if len(id) != 10 || !isDigits(id) {
+		return errTaxIDFormat
+	}
+	check := weightedSum(id, []int{6, 5, 7, 2, 3, 4, 5, 6, 7}) % 11
+	if check == 10 || check != int(id[9]-'0') {
+		return errTaxIDChecksum
+	}
+	return nil
+}
+
+// invalidTaxID returns a tax ID close to taxID that fails validation for
+// the region: the last digit bumped so the check digit no longer matches,
+// or failing that, the ID one character short or with a stray letter.
+func invalidTaxID(region, taxID string) string {
+	if taxID == "" {
+		return taxID
+	}
+	if i := strings.LastIndexFunc(taxID, func(r rune) bool { return r >= '0' && r <= '9' }); i >= 0 {
+		bumped := taxID[:i] + string('0'+(taxID[i]-'0'+1)%10) + taxID[i+1:]
+		if validateTaxID(region, bumped) != nil {
+			return bumped
+		}
+	}
+	if short := taxID[:len(taxID)-1]; validateTaxID(region, short) != nil {
+		return short
+	}
+	return taxID + "X"
+}
+
+// checkInvoiceTaxID serves an invoice's tax ID as the settings ask and
+// reports why it is invalid for the region, if it is.
+func checkInvoiceTaxID(region string, detail *InvoiceDetail) error {
+	if detail.TaxID == "" {
+		return nil
+	}
+	if invoiceSettings.get().InvalidTaxIDs {
+		detail.TaxID = invalidTaxID(region, detail.TaxID)
+	}
+	if err := validateTaxID(region, detail.TaxID); err != nil {
+		return fmt.Errorf("%w for %s", err, region)
+	}
+	return nil
+}
+
+// taxIDWarning reports why an invoice's tax ID is invalid for the region,
+// so seeded invoices with bad tax IDs are flagged when they are stored.
+func taxIDWarning(region string, invoice *BuyerInvoice) string {
+	if invoice == nil || invoice.InvoiceDetail.TaxID == "" {
+		return ""
+	}
+	if err := validateTaxID(region, invoice.InvoiceDetail.TaxID); err != nil {
+		return fmt.Sprintf("%v for %s", err, region)
+	}
+	return ""
+}
+
+// adminGetInvoiceSettings returns the invoice settings
+// @Summary Get invoice settings
+// @Description Returns whether get_buyer_invoice_info deliberately serves invalid tax IDs
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} InvoiceSettings "Current settings"
+// @Router /admin/invoice_settings [get]
+func adminGetInvoiceSettings(c *fiber.Ctx) error {
+	return c.JSON(invoiceSettings.get())
+}
+
+// adminSetInvoiceSettings changes the invoice settings
+// @Summary Set invoice settings
+// @Description With invalid_tax_ids on, get_buyer_invoice_info alters every tax ID so it fails its region's format or check digit, and flags it in the entry's error, to exercise client-side validation
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body InvoiceSettings true "New settings"
+// @Success 200 {object} InvoiceSettings "Updated settings"
+// @Failure 400 {object} map[string]interface{} "Invalid settings"
+// @Router /admin/invoice_settings [put]
+func adminSetInvoiceSettings(c *fiber.Ctx) error {
+	var settings InvoiceSettings
+
+	if err := c.BodyParser(&settings); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+
+	invoiceSettings.set(settings)
+	return c.JSON(settings)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTaxID(t *testing.T) {
+	tests := []struct {
+		region string
+		taxID  string
+		want   error
+	}{
+		{"TH", "0745561010054", nil},
+		{"TH", "3-1006-00445-79-1", nil},
+		{"TH", "0745561010055", errTaxIDChecksum},
+		{"TH", "074556101005", errTaxIDFormat},
+		{"VN", "0312345678", nil},
+		{"VN", "0312345678-001", nil},
+		{"VN", "031234567", errTaxIDFormat},
+		{"SG", "S1234567D", nil},
+		{"SG", "T1234567J", nil},
+		{"SG", "F1234567N", nil},
+		{"SG", "S1234567A", errTaxIDChecksum},
+		{"SG", "201912345K", nil},
+		{"SG", "T08LL1234A", nil},
+		{"SG", "2019123456", errTaxIDFormat},
+		{"MY", "C2584563202", nil},
+		{"MY", "2584563202", errTaxIDFormat},
+		{"PH", "123-456-789-000", nil},
+		{"PH", "12345678", errTaxIDFormat},
+		{"ID", "012345678901234", nil},
+		{"ID", "3201234567890001", nil},
+		{"ID", "01234567890123", errTaxIDFormat},
+		{"TW", "22099131", nil},
+		{"TW", "04595257", nil},
+		{"TW", "10458574", nil}, // valid only by counting 28 as 1
+		{"TW", "22099132", errTaxIDChecksum},
+		{"TW", "2209913", errTaxIDFormat},
+		{"BR", "529.982.247-25", nil},
+		{"BR", "111.444.777-35", nil},
+		{"BR", "529.982.247-24", errTaxIDChecksum},
+		{"BR", "111.111.111-11", errTaxIDChecksum},
+		{"BR", "11.222.333/0001-81", nil},
+		{"BR", "11.222.333/0001-80", errTaxIDChecksum},
+		{"MX", "GODE561231GR8", nil},
+		{"MX", "ABC680524P76", nil},
+		{"MX", "GODE5612GR8", errTaxIDFormat},
+		{"PL", "123-456-32-18", nil},
+		{"PL", "PL1234563218", nil},
+		{"PL", "1234563217", errTaxIDChecksum},
+		{"PL", "123456321", errTaxIDFormat},
+		{"XX", "anything", nil},
+	}
+
+	for _, tt := range tests {
+		if err := validateTaxID(tt.region, tt.taxID); !errors.Is(err, tt.want) {
+			t.Errorf("validateTaxID(%q, %q) = %v, want %v", tt.region, tt.taxID, err, tt.want)
+		}
+	}
+}
+
+func TestInvalidTaxID(t *testing.T) {
+	valid := map[string]string{
+		"TH": "0745561010054",
+		"VN": "0312345678",
+		"SG": "S1234567D",
+		"MY": "C2584563202",
+		"PH": "123-456-789-000",
+		"ID": "012345678901234",
+		"TW": "22099131",
+		"BR": "52998224725",
+		"MX": "GODE561231GR8",
+		"PL": "1234563218",
+	}
+
+	for region, taxID := range valid {
+		if err := validateTaxID(region, taxID); err != nil {
+			t.Fatalf("validateTaxID(%q, %q) = %v, want nil", region, taxID, err)
+		}
+		if invalid := invalidTaxID(region, taxID); validateTaxID(region, invalid) == nil {
+			t.Errorf("invalidTaxID(%q, %q) = %q, which is still valid", region, taxID, invalid)
+		}
+	}
+}
+
+func TestTaxIDWarning(t *testing.T) {
+	company := func(taxID string) *BuyerInvoice {
+		return &BuyerInvoice{InvoiceType: "company", InvoiceDetail: InvoiceDetail{TaxID: taxID}}
+	}
+
+	if got := taxIDWarning("TH", company("0745561010054")); got != "" {
+		t.Errorf("valid tax ID warned %q", got)
+	}
+	if got := taxIDWarning("TH", company("0745561010055")); got != "invalid tax_id check digit for TH" {
+		t.Errorf("invalid tax ID warned %q", got)
+	}
+	if got := taxIDWarning("TH", nil); got != "" {
+		t.Errorf("no invoice warned %q", got)
+	}
+}
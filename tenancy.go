@@ -0,0 +1,419 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Shopee access tokens last four hours and refresh tokens thirty days.
+const (
+	accessTokenTTL  = 4 * time.Hour
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// defaultMerchantID is the merchant that owns the default shop.
+const defaultMerchantID int64 = 1001
+
+var (
+	errShopNotFound     = errors.New("shop not found")
+	errMerchantNotFound = errors.New("merchant not found")
+	errShopExists       = errors.New("shop already exists")
+	errMerchantExists   = errors.New("merchant already exists")
+	errInvalidToken     = errors.New("Invalid access_token.")
+	errInvalidRefresh   = errors.New("Invalid refresh_token.")
+	errNoPermission     = errors.New("The access_token has no permission for this shop or merchant.")
+)
+
+// Shop is a seller shop. Orders, items and responses are scoped to it and
+// use its region and currency.
+type Shop struct {
+	ShopID     int64  `json:"shop_id" example:"789012"`
+	ShopName   string `json:"shop_name" example:"Mock Shop TH"`
+	Region     string `json:"region" example:"TH"`
+	Currency   string `json:"currency" example:"THB"`
+	MerchantID int64  `json:"merchant_id" example:"1001"`
+	Status     string `json:"status" example:"NORMAL"`
+}
+
+// Merchant is a main account that owns several shops and the global
+// products listed in them.
+type Merchant struct {
+	MerchantID   int64   `json:"merchant_id" example:"1001"`
+	MerchantName string  `json:"merchant_name" example:"Mock Merchant"`
+	Currency     string  `json:"currency" example:"THB"`
+	ShopIDList   []int64 `json:"shop_id_list" example:"789012"`
+}
+
+type ShopListResponse struct {
+	ShopList []Shop `json:"shop_list"`
+}
+
+type MerchantListResponse struct {
+	MerchantList []Merchant `json:"merchant_list"`
+}
+
+// accessToken grants access to the listed shops and merchants until it
+// expires on the virtual clock.
+type accessToken struct {
+	token          string
+	refreshToken   string
+	shopIDList     []int64
+	merchantIDList []int64
+	expireAt       time.Time
+	refreshAt      time.Time
+}
+
+func (t *accessToken) grantsShop(shopID int64) bool {
+	for _, id := range t.shopIDList {
+		if id == shopID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *accessToken) grantsMerchant(merchantID int64) bool {
+	for _, id := range t.merchantIDList {
+		if id == merchantID {
+			return true
+		}
+	}
+	return false
+}
+
+type tenantRegistry struct {
+	mu        sync.Mutex
+	shops     map[int64]*Shop
+	merchants map[int64]*Merchant
+	tokens    map[string]*accessToken
+	refreshes map[string]*accessToken
+}
+
+var tenants = newTenantRegistry()
+
+func newTenantRegistry() *tenantRegistry {
+	r := &tenantRegistry{
+		shops:     make(map[int64]*Shop),
+		merchants: make(map[int64]*Merchant),
+		tokens:    make(map[string]*accessToken),
+		refreshes: make(map[string]*accessToken),
+	}
+	r.merchants[defaultMerchantID] = &Merchant{
+		MerchantID:   defaultMerchantID,
+		MerchantName: "Mock Merchant",
+		Currency:     "THB",
+	}
+	r.shops[defaultShopID] = &Shop{
+		ShopID:     defaultShopID,
+		ShopName:   "Mock Shop TH",
+		Region:     "TH",
+		Currency:   "THB",
+		MerchantID: defaultMerchantID,
+		Status:     "NORMAL",
+	}
+
+	// The token used in the API examples never expires and may act for the
+	// default merchant and every shop it owns, so the mock works out of the
+	// box.
+	if token := getEnv("DEFAULT_ACCESS_TOKEN", "your_access_token"); token != "" {
+		r.tokens[token] = &accessToken{
+			token:          token,
+			merchantIDList: []int64{defaultMerchantID},
+		}
+	}
+	return r
+}
+
+func (r *tenantRegistry) shop(shopID int64) (Shop, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shop, ok := r.shops[shopID]
+	if !ok {
+		return Shop{}, false
+	}
+	return *shop, true
+}
+
+func (r *tenantRegistry) merchant(merchantID int64) (Merchant, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	merchant, ok := r.merchants[merchantID]
+	if !ok {
+		return Merchant{}, false
+	}
+	return r.merchantView(merchant), true
+}
+
+// merchantView returns a copy of a merchant with its shop list filled in.
+// Callers must hold r.mu.
+func (r *tenantRegistry) merchantView(merchant *Merchant) Merchant {
+	m := *merchant
+	m.ShopIDList = []int64{}
+	for _, shop := range r.shops {
+		if shop.MerchantID == merchant.MerchantID {
+			m.ShopIDList = append(m.ShopIDList, shop.ShopID)
+		}
+	}
+	sort.Slice(m.ShopIDList, func(i, j int) bool { return m.ShopIDList[i] < m.ShopIDList[j] })
+	return m
+}
+
+func (r *tenantRegistry) shopList() []Shop {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]Shop, 0, len(r.shops))
+	for _, shop := range r.shops {
+		list = append(list, *shop)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ShopID < list[j].ShopID })
+	return list
+}
+
+func (r *tenantRegistry) merchantList() []Merchant {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]Merchant, 0, len(r.merchants))
+	for _, merchant := range r.merchants {
+		list = append(list, r.merchantView(merchant))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].MerchantID < list[j].MerchantID })
+	return list
+}
+
+func (r *tenantRegistry) addShop(shop Shop) (Shop, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.shops[shop.ShopID]; ok {
+		return Shop{}, errShopExists
+	}
+	profile, ok := regionProfiles[shop.Region]
+	if !ok {
+		return Shop{}, fmt.Errorf("region %q is not supported", shop.Region)
+	}
+	if shop.Currency == "" {
+		shop.Currency = profile.Currency
+	}
+	if shop.Currency != profile.Currency {
+		return Shop{}, fmt.Errorf("currency of a %s shop must be %s", shop.Region, profile.Currency)
+	}
+	if _, ok := r.merchants[shop.MerchantID]; shop.MerchantID != 0 && !ok {
+		return Shop{}, errMerchantNotFound
+	}
+	if shop.Status == "" {
+		shop.Status = "NORMAL"
+	}
+	r.shops[shop.ShopID] = &shop
+	return shop, nil
+}
+
+func (r *tenantRegistry) addMerchant(merchant Merchant) (Merchant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.merchants[merchant.MerchantID]; ok {
+		return Merchant{}, errMerchantExists
+	}
+	merchant.ShopIDList = nil
+	r.merchants[merchant.MerchantID] = &merchant
+	return r.merchantView(&merchant), nil
+}
+
+// issue creates a token for one shop, or for a merchant and every shop it
+// owns.
+func (r *tenantRegistry) issue(shopID, merchantID int64, now time.Time) (*accessToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token := &accessToken{
+		token:        randomString(32),
+		refreshToken: randomString(32),
+		expireAt:     now.Add(accessTokenTTL),
+		refreshAt:    now.Add(refreshTokenTTL),
+	}
+	switch {
+	case merchantID != 0:
+		merchant, ok := r.merchants[merchantID]
+		if !ok {
+			return nil, errMerchantNotFound
+		}
+		token.merchantIDList = []int64{merchantID}
+		token.shopIDList = r.merchantView(merchant).ShopIDList
+	case shopID != 0:
+		if _, ok := r.shops[shopID]; !ok {
+			return nil, errShopNotFound
+		}
+		token.shopIDList = []int64{shopID}
+	default:
+		return nil, errors.New("shop_id or main_account_id is required")
+	}
+
+	r.tokens[token.token] = token
+	r.refreshes[token.refreshToken] = token
+	return token, nil
+}
+
+// refresh swaps a refresh token for a new access token with the same
+// grants. The old tokens stop working.
+func (r *tenantRegistry) refresh(refreshToken string, now time.Time) (*accessToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.refreshes[refreshToken]
+	if !ok || now.After(old.refreshAt) {
+		return nil, errInvalidRefresh
+	}
+	delete(r.tokens, old.token)
+	delete(r.refreshes, old.refreshToken)
+
+	token := &accessToken{
+		token:          randomString(32),
+		refreshToken:   randomString(32),
+		shopIDList:     old.shopIDList,
+		merchantIDList: old.merchantIDList,
+		expireAt:       now.Add(accessTokenTTL),
+		refreshAt:      now.Add(refreshTokenTTL),
+	}
+	r.tokens[token.token] = token
+	r.refreshes[token.refreshToken] = token
+	return token, nil
+}
+
+// authorize checks that an access token may act for a shop or, when
+// merchantID is set, a merchant. Merchant tokens may act for every shop the
+// merchant owns, including shops added after the token was issued.
+func (r *tenantRegistry) authorize(tokenValue string, shopID, merchantID int64, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenValue]
+	if !ok || (!token.expireAt.IsZero() && now.After(token.expireAt)) {
+		return errInvalidToken
+	}
+	if merchantID != 0 {
+		if token.grantsMerchant(merchantID) {
+			return nil
+		}
+		return errNoPermission
+	}
+	if token.grantsShop(shopID) {
+		return nil
+	}
+	if shop, ok := r.shops[shopID]; ok && token.grantsMerchant(shop.MerchantID) {
+		return nil
+	}
+	return errNoPermission
+}
+
+// allowAnonymous lets API requests without an access_token act for the
+// default shop, and only for it.
+var allowAnonymous = getEnv("ALLOW_ANONYMOUS_ACCESS", "") == "true"
+
+// scopeToTenant is a Fiber middleware that resolves the shop, or for global
+// product and merchant calls the merchant, a request acts for and checks
+// its access token. Requests without a shop_id act for the default shop.
+// Requests without an access_token are refused unless anonymous access is
+// allowed and they act for the default shop.
+func scopeToTenant(c *fiber.Ctx) error {
+	if publicAPI(c.Path()) {
+		return c.Next()
+	}
+
+	var shopID, merchantID int64
+	if merchantAPI(c.Path()) {
+		merchantID, _ = strconv.ParseInt(c.Query("merchant_id"), 10, 64)
+		merchant, ok := tenants.merchant(merchantID)
+		if !ok {
+			return tenantError(c, 403, "error_param", "Invalid merchant_id.")
+		}
+		c.Locals("merchant", merchant)
+	} else {
+		shopID = defaultShopID
+		if value := c.Query("shop_id"); value != "" {
+			shopID, _ = strconv.ParseInt(value, 10, 64)
+		}
+		shop, ok := tenants.shop(shopID)
+		if !ok {
+			return tenantError(c, 403, "error_param", "Invalid shop_id.")
+		}
+		c.Locals("shop", shop)
+	}
+
+	token := c.Query("access_token")
+	if token == "" {
+		if allowAnonymous && merchantID == 0 && shopID == defaultShopID {
+			return c.Next()
+		}
+		return tenantError(c, 403, "error_auth", errInvalidToken.Error())
+	}
+	if err := tenants.authorize(token, shopID, merchantID, clock.Now()); err != nil {
+		return tenantError(c, 403, "error_auth", err.Error())
+	}
+	return c.Next()
+}
+
+// publicAPI reports whether a path is one of the auth calls made before a
+// shop or merchant has an access token.
+func publicAPI(path string) bool {
+	return strings.HasPrefix(path, "/api/v2/auth/")
+}
+
+// merchantAPI reports whether a path is a merchant-level call, which acts
+// for a merchant_id rather than a shop_id.
+func merchantAPI(path string) bool {
+	return strings.HasPrefix(path, "/api/v2/global_product/") || strings.HasPrefix(path, "/api/v2/merchant/")
+}
+
+func tenantError(c *fiber.Ctx, status int, code, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"request_id": newRequestID(),
+		"error":      code,
+		"message":    message,
+	})
+}
+
+// resolveShopID returns shopID, or the default shop when it is unset.
+func resolveShopID(shopID int64) int64 {
+	if shopID == 0 {
+		return defaultShopID
+	}
+	return shopID
+}
+
+// requestShopID returns the shop a request acts for, resolved as
+// scopeToTenant does, for middleware that runs before it. Auth and
+// merchant-level calls act for no shop and give 0.
+func requestShopID(c *fiber.Ctx) int64 {
+	if publicAPI(c.Path()) || merchantAPI(c.Path()) {
+		return 0
+	}
+	shopID, _ := strconv.ParseInt(c.Query("shop_id"), 10, 64)
+	return resolveShopID(shopID)
+}
+
+// currentShop returns the shop a request was scoped to by scopeToTenant.
+func currentShop(c *fiber.Ctx) Shop {
+	if shop, ok := c.Locals("shop").(Shop); ok {
+		return shop
+	}
+	shop, _ := tenants.shop(defaultShopID)
+	return shop
+}
+
+// currentMerchant returns the merchant a global product request was scoped
+// to by scopeToTenant.
+func currentMerchant(c *fiber.Ctx) Merchant {
+	merchant, _ := c.Locals("merchant").(Merchant)
+	return merchant
+}
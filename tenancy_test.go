@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newTenancyTestApp() *fiber.App {
+	app := fiber.New()
+	app.Use("/api", scopeToTenant)
+	app.Get("/api/v2/shop/get_shop_info", getShopInfo)
+	app.Get("/api/v2/order/get_order_detail", getOrderDetail)
+	return app
+}
+
+func TestScopeToTenant(t *testing.T) {
+	const otherShopID = 5001
+	if _, err := tenants.addShop(Shop{ShopID: otherShopID, ShopName: "Other", Region: "VN"}); err != nil {
+		t.Fatal(err)
+	}
+	token, err := tenants.issue(otherShopID, 0, clock.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		anonymous bool
+		path      string
+		wantShop  string
+		wantError string
+	}{
+		{
+			name:      "no token",
+			path:      "/api/v2/shop/get_shop_info?shop_id=789012",
+			wantError: "error_auth",
+		},
+		{
+			name:      "anonymous default shop",
+			anonymous: true,
+			path:      "/api/v2/shop/get_shop_info",
+			wantShop:  "Mock Shop TH",
+		},
+		{
+			name:      "anonymous other shop",
+			anonymous: true,
+			path:      "/api/v2/shop/get_shop_info?shop_id=5001",
+			wantError: "error_auth",
+		},
+		{
+			name:      "unknown token",
+			path:      "/api/v2/shop/get_shop_info?shop_id=789012&access_token=nope",
+			wantError: "error_auth",
+		},
+		{
+			name:     "own shop",
+			path:     "/api/v2/shop/get_shop_info?shop_id=5001&access_token=" + token.token,
+			wantShop: "Other",
+		},
+		{
+			name:      "other shop's orders",
+			path:      "/api/v2/order/get_order_detail?shop_id=789012&order_sn_list=2404098R48U37H&access_token=" + token.token,
+			wantError: "error_auth",
+		},
+		{
+			name:      "default token on a shop of another merchant",
+			path:      "/api/v2/shop/get_shop_info?shop_id=5001&access_token=your_access_token",
+			wantError: "error_auth",
+		},
+	}
+
+	app := newTenancyTestApp()
+	defer func(allowed bool) { allowAnonymous = allowed }(allowAnonymous)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowAnonymous = tt.anonymous
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body struct {
+				Error    string `json:"error"`
+				ShopName string `json:"shop_name"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.Error != tt.wantError {
+				t.Fatalf("error = %q, want %q", body.Error, tt.wantError)
+			}
+			if tt.wantError != "" && resp.StatusCode != 403 {
+				t.Errorf("status = %d, want 403", resp.StatusCode)
+			}
+			if tt.wantShop != "" && body.ShopName != tt.wantShop {
+				t.Errorf("shop_name = %q, want %q", body.ShopName, tt.wantShop)
+			}
+		})
+	}
+}
+
+func TestRequestShopID(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		return c.JSON(requestShopID(c))
+	})
+
+	tests := []struct {
+		path string
+		want int64
+	}{
+		{"/api/v2/order/get_order_detail", defaultShopID},
+		{"/api/v2/order/get_order_detail?shop_id=5001", 5001},
+		{"/api/v2/merchant/get_shop_list_by_merchant?merchant_id=1001", 0},
+		{"/api/v2/auth/token/get", 0},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got int64
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: shop ID = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
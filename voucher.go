@@ -0,0 +1,633 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	maxVoucherDuration     = 180 * 24 * time.Hour
+	minVoucherDuration     = time.Hour
+	defaultVoucherPageSize = 20
+	maxVoucherPageSize     = 100
+
+	voucherTypeShop    = 1
+	voucherTypeProduct = 2
+
+	rewardTypeFixAmount  = 1
+	rewardTypePercentage = 2
+)
+
+var (
+	errVoucherNotFound = errors.New("voucher not found")
+	errInvalidVoucher  = errors.New("invalid voucher")
+)
+
+var voucherCodePattern = regexp.MustCompile(`^[A-Z0-9]{1,5}$`)
+
+type AddVoucherRequest struct {
+	VoucherName    string  `json:"voucher_name" example:"Payday voucher"`
+	VoucherCode    string  `json:"voucher_code" example:"PAY10"`
+	StartTime      int64   `json:"start_time" example:"1758279600"`
+	EndTime        int64   `json:"end_time" example:"1758884400"`
+	VoucherType    int     `json:"voucher_type" example:"1"`
+	RewardType     int     `json:"reward_type" example:"2"`
+	UsageQuantity  int     `json:"usage_quantity" example:"100"`
+	MinBasketPrice float64 `json:"min_basket_price" example:"200"`
+	DiscountAmount float64 `json:"discount_amount" example:"0"`
+	Percentage     int     `json:"percentage" example:"10"`
+	MaxPrice       float64 `json:"max_price" example:"50"`
+	ItemIDList     []int64 `json:"item_id_list"`
+}
+
+type VoucherIDResult struct {
+	VoucherID int64 `json:"voucher_id" example:"2000001"`
+}
+
+type AddVoucherResponse struct {
+	RequestID string           `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string           `json:"error" example:""`
+	Message   string           `json:"message" example:""`
+	Response  *VoucherIDResult `json:"response,omitempty"`
+}
+
+// UpdateVoucherRequest changes the fields that are set and leaves the rest
+// as they are.
+type UpdateVoucherRequest struct {
+	VoucherID      int64    `json:"voucher_id" example:"2000001"`
+	VoucherName    *string  `json:"voucher_name,omitempty" example:"Payday voucher"`
+	VoucherCode    *string  `json:"voucher_code,omitempty" example:"PAY10"`
+	StartTime      *int64   `json:"start_time,omitempty" example:"1758279600"`
+	EndTime        *int64   `json:"end_time,omitempty" example:"1758970800"`
+	RewardType     *int     `json:"reward_type,omitempty" example:"2"`
+	UsageQuantity  *int     `json:"usage_quantity,omitempty" example:"200"`
+	MinBasketPrice *float64 `json:"min_basket_price,omitempty" example:"200"`
+	DiscountAmount *float64 `json:"discount_amount,omitempty" example:"0"`
+	Percentage     *int     `json:"percentage,omitempty" example:"15"`
+	MaxPrice       *float64 `json:"max_price,omitempty" example:"50"`
+	ItemIDList     []int64  `json:"item_id_list,omitempty"`
+}
+
+type UpdateVoucherResponse struct {
+	RequestID string           `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string           `json:"error" example:""`
+	Message   string           `json:"message" example:""`
+	Response  *VoucherIDResult `json:"response,omitempty"`
+}
+
+type EndVoucherRequest struct {
+	VoucherID int64 `json:"voucher_id" example:"2000001"`
+}
+
+type EndVoucherResponse struct {
+	RequestID string           `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string           `json:"error" example:""`
+	Message   string           `json:"message" example:""`
+	Response  *VoucherIDResult `json:"response,omitempty"`
+}
+
+type Voucher struct {
+	VoucherID      int64   `json:"voucher_id" example:"2000001"`
+	VoucherCode    string  `json:"voucher_code" example:"PAY10"`
+	VoucherName    string  `json:"voucher_name" example:"Payday voucher"`
+	VoucherType    int     `json:"voucher_type" example:"1"`
+	RewardType     int     `json:"reward_type" example:"2"`
+	UsageQuantity  int     `json:"usage_quantity" example:"100"`
+	CurrentUsage   int     `json:"current_usage" example:"3"`
+	StartTime      int64   `json:"start_time" example:"1758279600"`
+	EndTime        int64   `json:"end_time" example:"1758884400"`
+	MinBasketPrice float64 `json:"min_basket_price" example:"200"`
+	DiscountAmount float64 `json:"discount_amount" example:"0"`
+	Percentage     int     `json:"percentage" example:"10"`
+	MaxPrice       float64 `json:"max_price" example:"50"`
+	ItemIDList     []int64 `json:"item_id_list"`
+	Status         string  `json:"status" example:"ongoing"`
+}
+
+type VoucherListResult struct {
+	VoucherList []Voucher `json:"voucher_list"`
+	More        bool      `json:"more" example:"false"`
+}
+
+type GetVoucherListResponse struct {
+	RequestID string             `json:"request_id" example:"a2c45ca2683caf1651ecab5a4d5942ce"`
+	Error     string             `json:"error" example:""`
+	Message   string             `json:"message" example:""`
+	Response  *VoucherListResult `json:"response,omitempty"`
+}
+
+type shopVoucher struct {
+	Voucher
+	shopID int64
+}
+
+// status is where a voucher is in its lifetime at a given time.
+func (v *shopVoucher) status(now time.Time) string {
+	switch {
+	case now.Unix() < v.StartTime:
+		return "upcoming"
+	case now.Unix() < v.EndTime:
+		return "ongoing"
+	default:
+		return "expired"
+	}
+}
+
+// validate checks a voucher's settings, apart from when it starts, which
+// only matters while it is upcoming.
+func (v *shopVoucher) validate() error {
+	start, end := time.Unix(v.StartTime, 0), time.Unix(v.EndTime, 0)
+	switch {
+	case strings.TrimSpace(v.VoucherName) == "":
+		return fmt.Errorf("%w: voucher_name is required", errInvalidVoucher)
+	case !voucherCodePattern.MatchString(v.VoucherCode):
+		return fmt.Errorf("%w: voucher_code must be 1 to 5 letters or digits", errInvalidVoucher)
+	case end.Sub(start) < minVoucherDuration:
+		return fmt.Errorf("%w: end_time must be at least an hour after start_time", errInvalidVoucher)
+	case end.Sub(start) > maxVoucherDuration:
+		return fmt.Errorf("%w: a voucher can run for at most 180 days", errInvalidVoucher)
+	case v.UsageQuantity <= 0:
+		return fmt.Errorf("%w: usage_quantity must be positive", errInvalidVoucher)
+	case v.UsageQuantity < v.CurrentUsage:
+		return fmt.Errorf("%w: usage_quantity must not be below the %d uses so far", errInvalidVoucher, v.CurrentUsage)
+	case v.MinBasketPrice < 0:
+		return fmt.Errorf("%w: min_basket_price must not be negative", errInvalidVoucher)
+	}
+
+	switch v.RewardType {
+	case rewardTypeFixAmount:
+		if v.DiscountAmount <= 0 {
+			return fmt.Errorf("%w: discount_amount must be positive", errInvalidVoucher)
+		}
+		v.Percentage, v.MaxPrice = 0, 0
+	case rewardTypePercentage:
+		if v.Percentage < 1 || v.Percentage > 99 {
+			return fmt.Errorf("%w: percentage must be between 1 and 99", errInvalidVoucher)
+		}
+		if v.MaxPrice < 0 {
+			return fmt.Errorf("%w: max_price must not be negative", errInvalidVoucher)
+		}
+		v.DiscountAmount = 0
+	default:
+		return fmt.Errorf("%w: reward_type must be 1 (fix amount) or 2 (percentage)", errInvalidVoucher)
+	}
+
+	switch v.VoucherType {
+	case voucherTypeShop:
+		v.ItemIDList = []int64{}
+	case voucherTypeProduct:
+		if len(v.ItemIDList) == 0 {
+			return fmt.Errorf("%w: item_id_list is required for product vouchers", errInvalidVoucher)
+		}
+		for _, itemID := range v.ItemIDList {
+			if _, ok := store.getItem(v.shopID, itemID); !ok {
+				return fmt.Errorf("%w: item %d", errItemNotFound, itemID)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: voucher_type must be 1 (shop) or 2 (product)", errInvalidVoucher)
+	}
+	return nil
+}
+
+// reward is what the voucher takes off an order of the given items at
+// their discounted prices. Product vouchers only count their own items.
+func (v *shopVoucher) reward(items []OrderItem, decimals int) (float64, error) {
+	var eligible float64
+	for _, item := range items {
+		if v.VoucherType == voucherTypeProduct && !containsInt64(v.ItemIDList, item.ItemID) {
+			continue
+		}
+		eligible += item.ModelDiscountedPrice * float64(item.ModelQuantityPurchased)
+	}
+	if eligible == 0 {
+		return 0, fmt.Errorf("%w: no item in the order is eligible for voucher %s", errInvalidVoucher, v.VoucherCode)
+	}
+	if eligible < v.MinBasketPrice {
+		return 0, fmt.Errorf("%w: voucher %s needs a basket of at least %v", errInvalidVoucher, v.VoucherCode, v.MinBasketPrice)
+	}
+
+	amount := v.DiscountAmount
+	if v.RewardType == rewardTypePercentage {
+		amount = eligible * float64(v.Percentage) / 100
+		if v.MaxPrice > 0 {
+			amount = min(amount, v.MaxPrice)
+		}
+	}
+	return roundPrice(min(amount, eligible), decimals), nil
+}
+
+// voucherStore keeps each shop's vouchers. Like discounts, vouchers are
+// never deleted; ending one moves its end time to the moment it was ended.
+type voucherStore struct {
+	mu       sync.Mutex
+	vouchers map[int64]*shopVoucher
+	nextID   int64
+}
+
+var vouchers = &voucherStore{
+	vouchers: make(map[int64]*shopVoucher),
+	nextID:   2000001,
+}
+
+// voucher returns a shop's voucher. Callers must hold s.mu.
+func (s *voucherStore) voucher(shopID, voucherID int64) (*shopVoucher, bool) {
+	voucher, ok := s.vouchers[voucherID]
+	if !ok || voucher.shopID != shopID {
+		return nil, false
+	}
+	return voucher, true
+}
+
+// byCode finds the shop's unexpired voucher with a code, other than the
+// one given. Callers must hold s.mu.
+func (s *voucherStore) byCode(shopID int64, code string, except int64, now time.Time) (*shopVoucher, bool) {
+	for _, voucher := range s.vouchers {
+		if voucher.shopID == shopID && voucher.VoucherID != except &&
+			voucher.VoucherCode == code && voucher.status(now) != "expired" {
+			return voucher, true
+		}
+	}
+	return nil, false
+}
+
+func (s *voucherStore) add(shopID int64, req AddVoucherRequest, now time.Time) (int64, error) {
+	voucher := &shopVoucher{
+		Voucher: Voucher{
+			VoucherCode:    strings.ToUpper(strings.TrimSpace(req.VoucherCode)),
+			VoucherName:    req.VoucherName,
+			VoucherType:    req.VoucherType,
+			RewardType:     req.RewardType,
+			UsageQuantity:  req.UsageQuantity,
+			StartTime:      req.StartTime,
+			EndTime:        req.EndTime,
+			MinBasketPrice: req.MinBasketPrice,
+			DiscountAmount: req.DiscountAmount,
+			Percentage:     req.Percentage,
+			MaxPrice:       req.MaxPrice,
+			ItemIDList:     append([]int64(nil), req.ItemIDList...),
+		},
+		shopID: shopID,
+	}
+	if !time.Unix(req.StartTime, 0).After(now) {
+		return 0, fmt.Errorf("%w: start_time must be in the future", errInvalidVoucher)
+	}
+	if err := voucher.validate(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if other, ok := s.byCode(shopID, voucher.VoucherCode, 0, now); ok {
+		return 0, fmt.Errorf("%w: voucher_code is already used by voucher %d", errInvalidVoucher, other.VoucherID)
+	}
+	voucher.VoucherID = s.nextID
+	s.nextID++
+	s.vouchers[voucher.VoucherID] = voucher
+	return voucher.VoucherID, nil
+}
+
+// update changes a voucher. Anything can change before the voucher starts;
+// once it is ongoing only its name, usage quantity and end time can, and
+// an expired voucher cannot change at all.
+func (s *voucherStore) update(shopID int64, req UpdateVoucherRequest, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	voucher, ok := s.voucher(shopID, req.VoucherID)
+	if !ok {
+		return errVoucherNotFound
+	}
+	status := voucher.status(now)
+	if status == "expired" {
+		return fmt.Errorf("%w: expired vouchers cannot be updated", errInvalidVoucher)
+	}
+	if status == "ongoing" && (req.VoucherCode != nil || req.StartTime != nil || req.RewardType != nil ||
+		req.MinBasketPrice != nil || req.DiscountAmount != nil || req.Percentage != nil || req.MaxPrice != nil ||
+		req.ItemIDList != nil) {
+		return fmt.Errorf("%w: only voucher_name, usage_quantity and end_time can change once a voucher has started", errInvalidVoucher)
+	}
+
+	updated := *voucher
+	updated.ItemIDList = append([]int64(nil), voucher.ItemIDList...)
+	if req.VoucherName != nil {
+		updated.VoucherName = *req.VoucherName
+	}
+	if req.VoucherCode != nil {
+		updated.VoucherCode = strings.ToUpper(strings.TrimSpace(*req.VoucherCode))
+	}
+	if req.StartTime != nil {
+		updated.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		updated.EndTime = *req.EndTime
+	}
+	if req.RewardType != nil {
+		updated.RewardType = *req.RewardType
+	}
+	if req.UsageQuantity != nil {
+		updated.UsageQuantity = *req.UsageQuantity
+	}
+	if req.MinBasketPrice != nil {
+		updated.MinBasketPrice = *req.MinBasketPrice
+	}
+	if req.DiscountAmount != nil {
+		updated.DiscountAmount = *req.DiscountAmount
+	}
+	if req.Percentage != nil {
+		updated.Percentage = *req.Percentage
+	}
+	if req.MaxPrice != nil {
+		updated.MaxPrice = *req.MaxPrice
+	}
+	if req.ItemIDList != nil {
+		updated.ItemIDList = append([]int64(nil), req.ItemIDList...)
+	}
+
+	switch {
+	case status == "upcoming" && !time.Unix(updated.StartTime, 0).After(now):
+		return fmt.Errorf("%w: start_time must be in the future", errInvalidVoucher)
+	case status == "ongoing" && !time.Unix(updated.EndTime, 0).After(now):
+		return fmt.Errorf("%w: end_time must be in the future; use end_voucher to end a voucher now", errInvalidVoucher)
+	}
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	if other, ok := s.byCode(shopID, updated.VoucherCode, updated.VoucherID, now); ok {
+		return fmt.Errorf("%w: voucher_code is already used by voucher %d", errInvalidVoucher, other.VoucherID)
+	}
+	*voucher = updated
+	return nil
+}
+
+// end stops an ongoing voucher now.
+func (s *voucherStore) end(shopID, voucherID int64, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	voucher, ok := s.voucher(shopID, voucherID)
+	if !ok {
+		return errVoucherNotFound
+	}
+	if voucher.status(now) != "ongoing" {
+		return fmt.Errorf("%w: only ongoing vouchers can be ended", errInvalidVoucher)
+	}
+	voucher.EndTime = now.Unix()
+	return nil
+}
+
+// list returns a shop's vouchers in a status, or all of them, newest first.
+func (s *voucherStore) list(shopID int64, status string, now time.Time) []Voucher {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []Voucher{}
+	for _, voucher := range s.vouchers {
+		if voucher.shopID != shopID {
+			continue
+		}
+		current := voucher.status(now)
+		if status != "all" && current != status {
+			continue
+		}
+		entry := voucher.Voucher
+		entry.Status = current
+		entry.ItemIDList = append([]int64{}, voucher.ItemIDList...)
+		list = append(list, entry)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].VoucherID > list[j].VoucherID })
+	return list
+}
+
+// quote returns what the shop's ongoing voucher with a code takes off an
+// order of the given items, without using it up.
+func (s *voucherStore) quote(shopID int64, code string, items []OrderItem, decimals int, now time.Time) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	voucher, err := s.usable(shopID, code, now)
+	if err != nil {
+		return 0, err
+	}
+	return voucher.reward(items, decimals)
+}
+
+// redeem counts one use of the shop's ongoing voucher with a code.
+func (s *voucherStore) redeem(shopID int64, code string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	voucher, err := s.usable(shopID, code, now)
+	if err != nil {
+		return err
+	}
+	voucher.CurrentUsage++
+	return nil
+}
+
+// release gives back a use counted by redeem.
+func (s *voucherStore) release(shopID int64, code string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if voucher, ok := s.byCode(shopID, code, 0, now); ok && voucher.CurrentUsage > 0 {
+		voucher.CurrentUsage--
+	}
+}
+
+// usable finds the shop's ongoing voucher with a code that has uses left.
+// Callers must hold s.mu.
+func (s *voucherStore) usable(shopID int64, code string, now time.Time) (*shopVoucher, error) {
+	voucher, ok := s.byCode(shopID, strings.ToUpper(code), 0, now)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errVoucherNotFound, code)
+	}
+	if voucher.status(now) != "ongoing" {
+		return nil, fmt.Errorf("%w: voucher %s has not started", errInvalidVoucher, voucher.VoucherCode)
+	}
+	if voucher.CurrentUsage >= voucher.UsageQuantity {
+		return nil, fmt.Errorf("%w: voucher %s is fully used", errInvalidVoucher, voucher.VoucherCode)
+	}
+	return voucher, nil
+}
+
+// addVoucher creates a voucher
+// @Summary Add voucher
+// @Description Creates a shop voucher (voucher_type 1) or a voucher for the items in item_id_list (voucher_type 2), taking a fixed discount_amount (reward_type 1) or a percentage capped at max_price (reward_type 2) off baskets of at least min_basket_price. It must start in the future and run for between an hour and 180 days, and its code must differ from the shop's other unexpired vouchers.
+// @Tags Voucher
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body AddVoucherRequest true "Voucher to create"
+// @Success 200 {object} AddVoucherResponse "Success response"
+// @Failure 400 {object} AddVoucherResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} AddVoucherResponse "Unknown item"
+// @Router /api/v2/voucher/add_voucher [post]
+func addVoucher(c *fiber.Ctx) error {
+	var req AddVoucherRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(AddVoucherResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	voucherID, err := vouchers.add(currentShop(c).ShopID, req, clock.Now())
+	if err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(AddVoucherResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(AddVoucherResponse{
+		RequestID: newRequestID(),
+		Response:  &VoucherIDResult{VoucherID: voucherID},
+	})
+}
+
+// updateVoucher changes a voucher
+// @Summary Update voucher
+// @Description Changes the fields given. An upcoming voucher can change anything; an ongoing one only its voucher_name, usage_quantity and a future end_time. Expired vouchers cannot change.
+// @Tags Voucher
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body UpdateVoucherRequest true "Voucher and the fields to change"
+// @Success 200 {object} UpdateVoucherResponse "Success response"
+// @Failure 400 {object} UpdateVoucherResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} UpdateVoucherResponse "Unknown voucher"
+// @Router /api/v2/voucher/update_voucher [post]
+func updateVoucher(c *fiber.Ctx) error {
+	var req UpdateVoucherRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(UpdateVoucherResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := vouchers.update(currentShop(c).ShopID, req, clock.Now()); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(UpdateVoucherResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(UpdateVoucherResponse{
+		RequestID: newRequestID(),
+		Response:  &VoucherIDResult{VoucherID: req.VoucherID},
+	})
+}
+
+// endVoucher ends an ongoing voucher
+// @Summary End voucher
+// @Description Ends an ongoing voucher now, so new orders can no longer use it
+// @Tags Voucher
+// @Accept json
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param request body EndVoucherRequest true "Voucher to end"
+// @Success 200 {object} EndVoucherResponse "Success response"
+// @Failure 400 {object} EndVoucherResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} EndVoucherResponse "Unknown voucher"
+// @Router /api/v2/voucher/end_voucher [post]
+func endVoucher(c *fiber.Ctx) error {
+	var req EndVoucherRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(EndVoucherResponse{
+			Error:   "error_param",
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := vouchers.end(currentShop(c).ShopID, req.VoucherID, clock.Now()); err != nil {
+		status, code := storeError(err)
+		return c.Status(status).JSON(EndVoucherResponse{
+			RequestID: newRequestID(),
+			Error:     code,
+			Message:   err.Error(),
+		})
+	}
+
+	return c.JSON(EndVoucherResponse{
+		RequestID: newRequestID(),
+		Response:  &VoucherIDResult{VoucherID: req.VoucherID},
+	})
+}
+
+// getVoucherList lists the shop's vouchers
+// @Summary Get voucher list
+// @Description Lists the shop's vouchers in a status on the virtual clock, newest first, with how often each has been used
+// @Tags Voucher
+// @Produce json
+// @Param partner_id query int64 true "Partner ID" example(123456)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param timestamp query int64 true "Request timestamp" example(1640995200)
+// @Param access_token query string true "Access token" example("your_access_token")
+// @Param sign query string true "Signature" example("ABCD1234567890EFGH")
+// @Param status query string true "upcoming, ongoing, expired or all" example("ongoing")
+// @Param page_no query int false "Page number, from 1" example(1)
+// @Param page_size query int false "Vouchers per page, at most 100" example(20)
+// @Success 200 {object} GetVoucherListResponse "Success response"
+// @Failure 400 {object} GetVoucherListResponse "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v2/voucher/get_voucher_list [get]
+func getVoucherList(c *fiber.Ctx) error {
+	status := c.Query("status")
+	switch status {
+	case "upcoming", "ongoing", "expired", "all":
+	default:
+		return c.Status(400).JSON(GetVoucherListResponse{
+			Error:   "error_param",
+			Message: "status must be upcoming, ongoing, expired or all",
+		})
+	}
+
+	list := vouchers.list(currentShop(c).ShopID, status, clock.Now())
+	start, end, err := pageRange(c.QueryInt("page_no"), c.QueryInt("page_size"), defaultVoucherPageSize, maxVoucherPageSize, len(list))
+	if err != nil {
+		return c.Status(400).JSON(GetVoucherListResponse{
+			Error:   "error_param",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(GetVoucherListResponse{
+		RequestID: newRequestID(),
+		Response: &VoucherListResult{
+			VoucherList: list[start:end],
+			More:        end < len(list),
+		},
+	})
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Push message codes, as listed in Shopee's push mechanism documentation.
+const (
+	pushOrderStatus   = 3
+	pushTrackingNo    = 4
+	pushReservedStock = 8
+	pushWebchat       = 10
+	pushVideoUpload   = 11
+)
+
+type PushMessage struct {
+	Code      int         `json:"code" example:"3"`
+	ShopID    int64       `json:"shop_id" example:"789012"`
+	Timestamp int64       `json:"timestamp" example:"1758274838"`
+	Data      interface{} `json:"data"`
+}
+
+type OrderStatusPush struct {
+	OrderSN           string `json:"ordersn" example:"2404098R48U37H"`
+	Status            string `json:"status" example:"READY_TO_SHIP"`
+	CompletedScenario string `json:"completed_scenario" example:""`
+	UpdateTime        int64  `json:"update_time" example:"1758274838"`
+}
+
+type TrackingNoPush struct {
+	OrderSN       string `json:"ordersn" example:"2404098R48U37H"`
+	ForderID      string `json:"forder_id" example:"5033428953898012345"`
+	PackageNumber string `json:"package_number" example:"OFG166300791210964"`
+	TrackingNo    string `json:"tracking_no" example:"TH0123456789"`
+}
+
+type ChangedValue struct {
+	Name string `json:"name" example:"reserved_stock"`
+	Old  int    `json:"old" example:"0"`
+	New  int    `json:"new" example:"1"`
+}
+
+type ReservedStockPush struct {
+	ShopID        int64          `json:"shop_id" example:"789012"`
+	ItemID        int64          `json:"item_id" example:"34001"`
+	VariationID   int64          `json:"variation_id" example:"0"`
+	Action        string         `json:"action" example:"ORDER_RESERVED"`
+	ChangedValues []ChangedValue `json:"changed_values"`
+	UpdateTime    int64          `json:"update_time" example:"1758274838"`
+}
+
+type VideoUploadPush struct {
+	VideoUploadID string             `json:"video_upload_id" example:"th_6f8d9f0ac1e24c8e9a7b3d21f05e4c77_000000"`
+	Status        string             `json:"status" example:"SUCCEEDED"`
+	Message       string             `json:"message" example:""`
+	VideoInfo     *UploadedVideoInfo `json:"video_info,omitempty"`
+}
+
+type WebhookConfig struct {
+	CallbackURL         string `json:"callback_url" example:"http://localhost:8080/shopee/push"`
+	MaxRetries          int    `json:"max_retries" example:"3"`
+	RetryBaseDelayMilli int64  `json:"retry_base_delay_ms" example:"1000"`
+}
+
+// webhookDispatcher delivers push messages to the configured callback URL.
+// Each message is sent on its own goroutine and retried with exponential
+// backoff until the consumer answers 2xx or the retries run out. Every
+// message and attempt is kept in a bounded delivery log.
+type webhookDispatcher struct {
+	mu         sync.Mutex
+	config     WebhookConfig
+	client     *http.Client
+	deliveries []*WebhookDelivery
+	nextID     int64
+}
+
+var webhooks = newWebhookDispatcher(WebhookConfig{
+	CallbackURL:         getEnv("WEBHOOK_URL", ""),
+	MaxRetries:          getEnvInt("WEBHOOK_MAX_RETRIES", 3),
+	RetryBaseDelayMilli: int64(getEnvInt("WEBHOOK_RETRY_BASE_DELAY_MS", 1000)),
+})
+
+func newWebhookDispatcher(config WebhookConfig) *webhookDispatcher {
+	return &webhookDispatcher{
+		config: config,
+		client: &http.Client{Timeout: 5 * time.Second},
+		nextID: 1,
+	}
+}
+
+func (d *webhookDispatcher) getConfig() WebhookConfig {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.config
+}
+
+func (d *webhookDispatcher) setConfig(config WebhookConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.config = config
+}
+
+// push logs a push message and queues it for delivery. It never blocks, so
+// callers may hold store locks. Messages are logged even while no callback
+// URL is set so they can be replayed once one is.
+func (d *webhookDispatcher) push(code int, shopID int64, data interface{}) {
+	now := clock.Now()
+	body, err := json.Marshal(PushMessage{
+		Code:      code,
+		ShopID:    shopID,
+		Timestamp: now.Unix(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("webhook: marshal code %d push: %v", code, err)
+		return
+	}
+
+	delivery, config := d.record(code, shopID, body, now)
+	if config.CallbackURL == "" {
+		return
+	}
+	go d.deliver(delivery, config, false)
+}
+
+func (d *webhookDispatcher) deliver(delivery *WebhookDelivery, config WebhookConfig, redelivery bool) {
+	delay := time.Duration(config.RetryBaseDelayMilli) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		status, err := d.send(delivery, config.CallbackURL, redelivery, attempt)
+		if err == nil && status >= 200 && status < 300 {
+			return
+		}
+		if attempt >= config.MaxRetries {
+			log.Printf("webhook: giving up on %s after %d attempts (status %d, err %v)", config.CallbackURL, attempt+1, status, err)
+			d.finish(delivery, deliveryFailed)
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (d *webhookDispatcher) send(delivery *WebhookDelivery, url string, redelivery bool, retry int) (int, error) {
+	signature := pushSignature(url, delivery.Payload)
+	sentAt := clock.Now()
+	started := time.Now()
+
+	status, err := func() (int, error) {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(delivery.Payload))
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", signature)
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}()
+
+	attempt := WebhookAttempt{
+		SentAt:       sentAt.UnixMilli(),
+		CallbackURL:  url,
+		Signature:    signature,
+		ResponseCode: status,
+		LatencyMilli: time.Since(started).Milliseconds(),
+		Retry:        retry,
+		Redelivery:   redelivery,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	d.addAttempt(delivery, attempt)
+	return status, err
+}
+
+// pushSignature signs a push the way Shopee does: HMAC-SHA256 over the
+// callback URL and body joined by "|", keyed with the partner key and hex
+// encoded in lower case.
+func pushSignature(url string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(PartnerKey))
+	h.Write([]byte(url + "|"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func pushOrderStatusChange(shopID int64, order OrderDetail) {
+	webhooks.push(pushOrderStatus, shopID, OrderStatusPush{
+		OrderSN:    order.OrderSN,
+		Status:     order.OrderStatus,
+		UpdateTime: order.UpdateTime,
+	})
+}
+
+func pushReservedStockChange(shopID int64, item ItemDetail, action string, oldReserved int) {
+	webhooks.push(pushReservedStock, shopID, ReservedStockPush{
+		ShopID: shopID,
+		ItemID: item.ItemID,
+		Action: action,
+		ChangedValues: []ChangedValue{{
+			Name: "reserved_stock",
+			Old:  oldReserved,
+			New:  item.StockInfoV2.SummaryInfo.TotalReservedStock,
+		}},
+		UpdateTime: item.UpdateTime,
+	})
+}
+
+// adminGetWebhook returns the push delivery settings
+// @Summary Get webhook config
+// @Description Returns the callback URL and retry settings used for push messages
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} WebhookConfig "Current settings"
+// @Router /admin/webhook [get]
+func adminGetWebhook(c *fiber.Ctx) error {
+	return c.JSON(webhooks.getConfig())
+}
+
+// adminSetWebhook changes the push delivery settings
+// @Summary Set webhook config
+// @Description Sets the callback URL and retry settings used for push messages. An empty callback_url disables pushes.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body WebhookConfig true "New settings"
+// @Success 200 {object} WebhookConfig "Updated settings"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/webhook [put]
+func adminSetWebhook(c *fiber.Ctx) error {
+	var req WebhookConfig
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.MaxRetries < 0 || req.RetryBaseDelayMilli < 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error":   "error_param",
+			"message": "max_retries and retry_base_delay_ms must not be negative",
+		})
+	}
+
+	webhooks.setConfig(req)
+	return c.JSON(req)
+}
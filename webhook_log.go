@@ -0,0 +1,329 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxWebhookDeliveries bounds the delivery log; the oldest entries are
+// dropped first.
+const maxWebhookDeliveries = 10000
+
+const (
+	deliveryPending   = "pending"
+	deliveryDelivered = "delivered"
+	deliveryFailed    = "failed"
+	deliverySkipped   = "skipped"
+)
+
+var (
+	errDeliveryNotFound = errors.New("delivery not found")
+	errNoCallbackURL    = errors.New("no callback_url is configured")
+)
+
+type WebhookAttempt struct {
+	SentAt       int64  `json:"sent_at" example:"1758274838123"`
+	CallbackURL  string `json:"callback_url" example:"http://localhost:8080/shopee/push"`
+	Signature    string `json:"signature" example:"6d1f0c2a9e..."`
+	ResponseCode int    `json:"response_code" example:"200"`
+	LatencyMilli int64  `json:"latency_ms" example:"12"`
+	Retry        int    `json:"retry" example:"0"`
+	Redelivery   bool   `json:"redelivery" example:"false"`
+	Error        string `json:"error" example:""`
+}
+
+// WebhookDelivery is one push message and every attempt made to deliver it.
+type WebhookDelivery struct {
+	ID         int64            `json:"id" example:"1"`
+	Code       int              `json:"code" example:"3"`
+	ShopID     int64            `json:"shop_id" example:"789012"`
+	CreatedAt  int64            `json:"created_at" example:"1758274838"`
+	Payload    json.RawMessage  `json:"payload" swaggertype:"object"`
+	Status     string           `json:"status" example:"delivered"`
+	RetryCount int              `json:"retry_count" example:"0"`
+	Attempts   []WebhookAttempt `json:"attempts"`
+}
+
+type WebhookDeliveryFilter struct {
+	Code   int    `query:"code" example:"3"`
+	ShopID int64  `query:"shop_id" example:"789012"`
+	Status string `query:"status" example:"failed"`
+	From   int64  `query:"from" example:"1758274800"`
+	To     int64  `query:"to" example:"1758278400"`
+}
+
+type WebhookDeliveryListResponse struct {
+	DeliveryList []WebhookDelivery `json:"delivery_list"`
+}
+
+type WebhookReplayRequest struct {
+	From int64 `json:"from" example:"1758274800"`
+	To   int64 `json:"to" example:"1758278400"`
+	Code int   `json:"code" example:"3"`
+}
+
+type WebhookReplayResponse struct {
+	Replayed     int               `json:"replayed" example:"2"`
+	DeliveryList []WebhookDelivery `json:"delivery_list"`
+}
+
+func (f WebhookDeliveryFilter) matches(delivery *WebhookDelivery) bool {
+	switch {
+	case f.Code != 0 && delivery.Code != f.Code:
+		return false
+	case f.ShopID != 0 && delivery.ShopID != f.ShopID:
+		return false
+	case f.Status != "" && delivery.Status != f.Status:
+		return false
+	case f.From != 0 && delivery.CreatedAt < f.From:
+		return false
+	case f.To != 0 && delivery.CreatedAt > f.To:
+		return false
+	}
+	return true
+}
+
+// record adds a push message to the delivery log and returns it together
+// with the config it should be delivered with.
+func (d *webhookDispatcher) record(code int, shopID int64, body []byte, created time.Time) (*WebhookDelivery, WebhookConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery := &WebhookDelivery{
+		ID:        d.nextID,
+		Code:      code,
+		ShopID:    shopID,
+		CreatedAt: created.Unix(),
+		Payload:   body,
+		Status:    deliveryPending,
+		Attempts:  []WebhookAttempt{},
+	}
+	if d.config.CallbackURL == "" {
+		delivery.Status = deliverySkipped
+	}
+	d.nextID++
+
+	d.deliveries = append(d.deliveries, delivery)
+	if len(d.deliveries) > maxWebhookDeliveries {
+		d.deliveries = d.deliveries[len(d.deliveries)-maxWebhookDeliveries:]
+	}
+	return delivery, d.config
+}
+
+func (d *webhookDispatcher) addAttempt(delivery *WebhookDelivery, attempt WebhookAttempt) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	if attempt.Retry > 0 {
+		delivery.RetryCount++
+	}
+	if attempt.Error == "" && attempt.ResponseCode >= 200 && attempt.ResponseCode < 300 {
+		delivery.Status = deliveryDelivered
+	} else if attempt.Redelivery {
+		delivery.Status = deliveryFailed
+	}
+}
+
+func (d *webhookDispatcher) finish(delivery *WebhookDelivery, status string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delivery.Status = status
+}
+
+// list returns copies of the logged deliveries matching filter, oldest
+// first.
+func (d *webhookDispatcher) list(filter WebhookDeliveryFilter) []WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	list := []WebhookDelivery{}
+	for _, delivery := range d.deliveries {
+		if filter.matches(delivery) {
+			list = append(list, copyDelivery(delivery))
+		}
+	}
+	return list
+}
+
+func (d *webhookDispatcher) find(id int64) (*WebhookDelivery, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, delivery := range d.deliveries {
+		if delivery.ID == id {
+			return delivery, true
+		}
+	}
+	return nil, false
+}
+
+func (d *webhookDispatcher) get(id int64) (WebhookDelivery, error) {
+	delivery, ok := d.find(id)
+	if !ok {
+		return WebhookDelivery{}, errDeliveryNotFound
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return copyDelivery(delivery), nil
+}
+
+// redeliver sends a logged push message again, byte for byte, to the
+// current callback URL. It makes a single attempt and waits for it.
+func (d *webhookDispatcher) redeliver(id int64) (WebhookDelivery, error) {
+	delivery, ok := d.find(id)
+	if !ok {
+		return WebhookDelivery{}, errDeliveryNotFound
+	}
+	config := d.getConfig()
+	if config.CallbackURL == "" {
+		return WebhookDelivery{}, errNoCallbackURL
+	}
+
+	d.send(delivery, config.CallbackURL, true, 0)
+	return d.get(id)
+}
+
+// replay redelivers every logged push message created in [from, to], in the
+// order they were first pushed.
+func (d *webhookDispatcher) replay(filter WebhookDeliveryFilter) ([]WebhookDelivery, error) {
+	if d.getConfig().CallbackURL == "" {
+		return nil, errNoCallbackURL
+	}
+
+	replayed := []WebhookDelivery{}
+	for _, delivery := range d.list(filter) {
+		result, err := d.redeliver(delivery.ID)
+		if err != nil {
+			return nil, err
+		}
+		replayed = append(replayed, result)
+	}
+	return replayed, nil
+}
+
+func copyDelivery(delivery *WebhookDelivery) WebhookDelivery {
+	c := *delivery
+	c.Attempts = append([]WebhookAttempt{}, delivery.Attempts...)
+	return c
+}
+
+func webhookLogError(c *fiber.Ctx, err error) error {
+	status := 400
+	if errors.Is(err, errDeliveryNotFound) {
+		status = 404
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":   "error_param",
+		"message": err.Error(),
+	})
+}
+
+// adminListDeliveries lists logged push messages
+// @Summary List webhook deliveries
+// @Description Lists logged push messages with their payload, signature, response codes, latency and retry counts
+// @Tags Admin
+// @Produce json
+// @Param code query int false "Push code" example(3)
+// @Param shop_id query int64 false "Shop ID" example(789012)
+// @Param status query string false "Delivery status: pending, delivered, failed or skipped" example("failed")
+// @Param from query int64 false "Earliest creation time, unix seconds" example(1758274800)
+// @Param to query int64 false "Latest creation time, unix seconds" example(1758278400)
+// @Success 200 {object} WebhookDeliveryListResponse "Matching deliveries, oldest first"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/webhook/deliveries [get]
+func adminListDeliveries(c *fiber.Ctx) error {
+	var filter WebhookDeliveryFilter
+
+	if err := c.QueryParser(&filter); err != nil {
+		return webhookLogError(c, errors.New("Invalid query parameters"))
+	}
+
+	return c.JSON(WebhookDeliveryListResponse{
+		DeliveryList: webhooks.list(filter),
+	})
+}
+
+// adminGetDelivery returns one logged push message
+// @Summary Get webhook delivery
+// @Description Returns one logged push message with every delivery attempt
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Delivery ID" example(1)
+// @Success 200 {object} WebhookDelivery "Delivery"
+// @Failure 404 {object} map[string]interface{} "Unknown delivery"
+// @Router /admin/webhook/deliveries/{id} [get]
+func adminGetDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return webhookLogError(c, errDeliveryNotFound)
+	}
+
+	delivery, err := webhooks.get(id)
+	if err != nil {
+		return webhookLogError(c, err)
+	}
+	return c.JSON(delivery)
+}
+
+// adminRedeliver sends one logged push message again
+// @Summary Redeliver webhook
+// @Description Sends a logged push message again with the identical payload and waits for the consumer's answer
+// @Tags Admin
+// @Produce json
+// @Param id path int64 true "Delivery ID" example(1)
+// @Success 200 {object} WebhookDelivery "Delivery including the new attempt"
+// @Failure 400 {object} map[string]interface{} "No callback URL configured"
+// @Failure 404 {object} map[string]interface{} "Unknown delivery"
+// @Router /admin/webhook/deliveries/{id}/redeliver [post]
+func adminRedeliver(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return webhookLogError(c, errDeliveryNotFound)
+	}
+
+	delivery, err := webhooks.redeliver(id)
+	if err != nil {
+		return webhookLogError(c, err)
+	}
+	return c.JSON(delivery)
+}
+
+// adminReplayDeliveries replays logged push messages from a time range
+// @Summary Replay webhooks
+// @Description Sends every logged push message created in a time range again, in the original order
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body WebhookReplayRequest true "Time range, unix seconds, and optional push code"
+// @Success 200 {object} WebhookReplayResponse "Replayed deliveries"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /admin/webhook/replay [post]
+func adminReplayDeliveries(c *fiber.Ctx) error {
+	var req WebhookReplayRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return webhookLogError(c, errors.New("Invalid request body"))
+	}
+
+	if req.From == 0 || req.To == 0 || req.To < req.From {
+		return webhookLogError(c, errors.New("from and to are required and to must not be before from"))
+	}
+
+	replayed, err := webhooks.replay(WebhookDeliveryFilter{
+		Code: req.Code,
+		From: req.From,
+		To:   req.To,
+	})
+	if err != nil {
+		return webhookLogError(c, err)
+	}
+	return c.JSON(WebhookReplayResponse{
+		Replayed:     len(replayed),
+		DeliveryList: replayed,
+	})
+}